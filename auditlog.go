@@ -0,0 +1,337 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains functions to decode audit log changes, resolve the
+// targets of audit log entries and iterate over the audit log of a guild.
+
+package discordgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// AuditLogRole is a role as it appears in the $add and $remove changes of
+// an audit log entry.
+type AuditLogRole struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UnmarshalJSON is a helper function to unmarshal AuditLogChange, keeping
+// the raw values so they can be decoded with DecodeNew and DecodeOld.
+func (c *AuditLogChange) UnmarshalJSON(data []byte) error {
+	type auditLogChange AuditLogChange
+	var v struct {
+		auditLogChange
+		RawNewValue json.RawMessage `json:"new_value"`
+		RawOldValue json.RawMessage `json:"old_value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*c = AuditLogChange(v.auditLogChange)
+	c.rawNewValue = v.RawNewValue
+	c.rawOldValue = v.RawOldValue
+	if err := json.Unmarshal(v.RawNewValue, &c.NewValue); len(v.RawNewValue) > 0 && err != nil {
+		return err
+	}
+	if err := json.Unmarshal(v.RawOldValue, &c.OldValue); len(v.RawOldValue) > 0 && err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeNew decodes the new value of the change into v.
+// It does nothing if the change has no new value.
+func (c *AuditLogChange) DecodeNew(v interface{}) error {
+	return decodeAuditLogValue(c.rawNewValue, v)
+}
+
+// DecodeOld decodes the old value of the change into v.
+// It does nothing if the change has no old value.
+func (c *AuditLogChange) DecodeOld(v interface{}) error {
+	return decodeAuditLogValue(c.rawOldValue, v)
+}
+
+// Values returns the old and new value of the change decoded into the type
+// which belongs to its key:
+//
+//	[]*AuditLogRole          for AuditLogChangeKeyRoleAdd and AuditLogChangeKeyRoleRemove
+//	[]*PermissionOverwrite   for AuditLogChangeKeyPermissionOverwrite
+//	int64                    for permissions, e.g. AuditLogChangeKeyPermissions
+//	Timestamp                for AuditLogChangeKeyCommunicationDisabledUntil
+//	VerificationLevel, ExplicitContentFilterLevel, MfaLevel,
+//	MessageNotifications and ExpireBehavior for the keys of the same name
+//	string, int or bool      for the other known keys
+//
+// Values of unknown keys are returned as decoded by encoding/json.
+// A missing value is returned as nil.
+func (c *AuditLogChange) Values() (oldValue, newValue interface{}, err error) {
+	if c.Key == nil {
+		return c.OldValue, c.NewValue, nil
+	}
+
+	oldValue, err = auditLogValue(*c.Key, c.rawOldValue)
+	if err != nil {
+		return
+	}
+	newValue, err = auditLogValue(*c.Key, c.rawNewValue)
+	return
+}
+
+// decodeAuditLogValue decodes a raw audit log value into v, accepting
+// permissions sent as strings for *int64.
+func decodeAuditLogValue(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	if p, ok := v.(*int64); ok {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrJSONUnmarshal, err)
+			}
+			*p = i
+			return nil
+		}
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrJSONUnmarshal, err)
+	}
+	return nil
+}
+
+// auditLogValue decodes raw into the type belonging to key.
+func auditLogValue(key AuditLogChangeKey, raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var err error
+	switch key {
+	case AuditLogChangeKeyRoleAdd, AuditLogChangeKeyRoleRemove:
+		var v []*AuditLogRole
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyPermissionOverwrite:
+		var v []*PermissionOverwrite
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyPermissions, AuditLogChangeKeyAllow, AuditLogChangeKeyDeny:
+		var v int64
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyCommunicationDisabledUntil:
+		var v Timestamp
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyVerificationLevel:
+		var v VerificationLevel
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyExplicitContentFilter:
+		var v ExplicitContentFilterLevel
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyMfaLevel:
+		var v MfaLevel
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyDefaultMessageNotification:
+		var v MessageNotifications
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyExpireBehavior:
+		var v ExpireBehavior
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyAfkTimeout, AuditLogChangeKeyPruneDeleteDays, AuditLogChangeKeyPosition,
+		AuditLogChangeKeyBitrate, AuditLogChangeKeyRateLimitPerUser, AuditLogChangeKeyColor,
+		AuditLogChangeKeyMaxUses, AuditLogChangeKeyUses, AuditLogChangeKeyMaxAge,
+		AuditLogChangeKeyExpireGracePeriod:
+		var v int
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyWidgetEnabled, AuditLogChangeKeyNSFW, AuditLogChangeKeyHoist,
+		AuditLogChangeKeyMentionable, AuditLogChangeKeyTempoary, AuditLogChangeKeyDeaf,
+		AuditLogChangeKeyMute, AuditLogChangeKeyEnableEmoticons:
+		var v bool
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+
+	case AuditLogChangeKeyName, AuditLogChangeKeyIconHash, AuditLogChangeKeySplashHash,
+		AuditLogChangeKeyOwnerID, AuditLogChangeKeyRegion, AuditLogChangeKeyAfkChannelID,
+		AuditLogChangeKeyVanityURLCode, AuditLogChangeKeyWidgetChannelID, AuditLogChangeKeySystemChannelID,
+		AuditLogChangeKeyTopic, AuditLogChangeKeyApplicationID, AuditLogChangeKeyCode,
+		AuditLogChangeKeyChannelID, AuditLogChangeKeyInviterID, AuditLogChangeKeyNick,
+		AuditLogChangeKeyAvatarHash, AuditLogChangeKeyID:
+		var v string
+		err = decodeAuditLogValue(raw, &v)
+		return v, err
+	}
+
+	// AuditLogChangeKeyType is a string for integrations and a number for
+	// channels, so it is left to encoding/json as are unknown keys.
+	var v interface{}
+	err = decodeAuditLogValue(raw, &v)
+	return v, err
+}
+
+// User returns the user with the given ID from the users of the audit log,
+// or nil if it is not included.
+func (l *GuildAuditLog) User(userID string) *User {
+	for _, u := range l.Users {
+		if u.ID == userID {
+			return u
+		}
+	}
+	return nil
+}
+
+// Webhook returns the webhook with the given ID from the webhooks of the
+// audit log, or nil if it is not included.
+func (l *GuildAuditLog) Webhook(webhookID string) *Webhook {
+	for _, w := range l.Webhooks {
+		if w.ID == webhookID {
+			return w
+		}
+	}
+	return nil
+}
+
+// Integration returns the integration with the given ID from the
+// integrations of the audit log, or nil if it is not included.
+func (l *GuildAuditLog) Integration(integrationID string) *Integration {
+	for _, i := range l.Integrations {
+		if i.ID == integrationID {
+			return i
+		}
+	}
+	return nil
+}
+
+// Target resolves the TargetID of entry against the users, webhooks and
+// integrations of the audit log, depending on its action type. It returns
+// a *User, *Webhook or *Integration, or nil if the target is of another
+// type, e.g. a channel or role, or is not included in the audit log.
+func (l *GuildAuditLog) Target(entry *AuditLogEntry) interface{} {
+	if entry.ActionType == nil || entry.TargetID == "" {
+		return nil
+	}
+
+	switch *entry.ActionType {
+	case AuditLogActionMemberKick, AuditLogActionMemberBanAdd, AuditLogActionMemberBanRemove,
+		AuditLogActionMemberUpdate, AuditLogActionMemberRoleUpdate, AuditLogActionBotAdd,
+		AuditLogActionMessageDelete:
+		if u := l.User(entry.TargetID); u != nil {
+			return u
+		}
+	case AuditLogActionWebhookCreate, AuditLogActionWebhookUpdate, AuditLogActionWebhookDelete:
+		if w := l.Webhook(entry.TargetID); w != nil {
+			return w
+		}
+	case AuditLogActionIntegrationCreate, AuditLogActionIntegrationUpdate, AuditLogActionIntegrationDelete:
+		if i := l.Integration(entry.TargetID); i != nil {
+			return i
+		}
+	}
+	return nil
+}
+
+// GuildAuditLogParams are the parameters of GuildAuditLogComplex and
+// GuildAuditLogIterator.
+type GuildAuditLogParams struct {
+	// Only return entries of actions by this user.
+	UserID string
+
+	// Only return entries of this action type.
+	ActionType AuditLogAction
+
+	// Only return entries before or after the entry with this ID.
+	BeforeID string
+	AfterID  string
+
+	// The number of entries to return per request (max 100).
+	Limit int
+}
+
+// AuditLogIterator walks the audit log of a guild from the newest to the
+// oldest entry, requesting one page after another.
+//
+//	it := s.GuildAuditLogIterator(guildID, nil)
+//	for it.Next() {
+//		entry := it.Entry()
+//		user, _ := it.Log().Target(entry).(*User)
+//	}
+//	if err := it.Err(); err != nil { ... }
+type AuditLogIterator struct {
+	paginator
+	log *GuildAuditLog
+}
+
+// GuildAuditLogIterator returns an iterator over the audit log of a guild,
+// from the newest entry, or the one before params.BeforeID, back to the
+// oldest one, or the one after params.AfterID.
+// guildID : The ID of a Guild.
+// params  : The parameters of the requests, may be nil.
+func (s *Session) GuildAuditLogIterator(guildID string, params *GuildAuditLogParams, options ...RequestOption) *AuditLogIterator {
+	var p GuildAuditLogParams
+	if params != nil {
+		p = *params
+	}
+
+	// AfterID only bounds the walk, as Discord returns the oldest entries
+	// first for after.
+	pageParams := &PageParams{
+		Direction: PageBackward,
+		StartID:   p.BeforeID,
+		StopID:    p.AfterID,
+		PageSize:  p.Limit,
+	}
+	p.AfterID = ""
+
+	it := &AuditLogIterator{}
+	it.paginator = newPaginator(pageParams, 100, true, true, func(beforeID, afterID string, limit int) (n int, err error) {
+		p.BeforeID = beforeID
+		p.Limit = limit
+		it.log, err = s.GuildAuditLogComplex(guildID, &p, options...)
+		if err != nil {
+			return
+		}
+		return len(it.log.AuditLogEntries), nil
+	}, func(i int) string {
+		return it.log.AuditLogEntries[i].ID
+	})
+	return it
+}
+
+// Entry returns the current entry.
+func (it *AuditLogIterator) Entry() *AuditLogEntry {
+	return it.log.AuditLogEntries[it.index()]
+}
+
+// Log returns the page of the current entry, to resolve its target and
+// the users, webhooks and integrations it refers to.
+func (it *AuditLogIterator) Log() *GuildAuditLog {
+	return it.log
+}
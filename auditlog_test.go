@@ -0,0 +1,92 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogChangeValues(t *testing.T) {
+	data := `{"changes": [
+		{"key": "$add", "new_value": [{"id": "1", "name": "mod"}]},
+		{"key": "permissions", "old_value": "8", "new_value": "2048"},
+		{"key": "nsfw", "old_value": false, "new_value": true},
+		{"key": "name", "old_value": "old", "new_value": "new"}
+	]}`
+
+	var entry AuditLogEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+
+	want := [][2]interface{}{
+		{nil, []*AuditLogRole{{ID: "1", Name: "mod"}}},
+		{int64(8), int64(2048)},
+		{false, true},
+		{"old", "new"},
+	}
+	for i, c := range entry.Changes {
+		oldValue, newValue, err := c.Values()
+		if err != nil {
+			t.Errorf("change %d: Values returned error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, want[i][0]) || !reflect.DeepEqual(newValue, want[i][1]) {
+			t.Errorf("change %d: got %#v, %#v, want %#v, %#v", i, oldValue, newValue, want[i][0], want[i][1])
+		}
+	}
+
+	if entry.Changes[3].NewValue != "new" {
+		t.Errorf("NewValue: got %#v, want %#v", entry.Changes[3].NewValue, "new")
+	}
+}
+
+func TestGuildAuditLogTarget(t *testing.T) {
+	kick := AuditLogActionMemberKick
+	log := &GuildAuditLog{Users: []*User{{ID: "1"}, {ID: "2"}}}
+
+	u, ok := log.Target(&AuditLogEntry{TargetID: "2", ActionType: &kick}).(*User)
+	if !ok || u.ID != "2" {
+		t.Errorf("Target returned %#v, want user 2", u)
+	}
+}
+
+func TestGuildAuditLogIterator(t *testing.T) {
+	var befores []string
+	s, closeServer := newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		before := r.URL.Query().Get("before")
+		befores = append(befores, before)
+
+		// Entries 10 to 1, two per page.
+		first := 10
+		if before != "" {
+			fmt.Sscan(before, &first)
+			first--
+		}
+		var entries []string
+		for id := first; id > first-2 && id > 0; id-- {
+			entries = append(entries, fmt.Sprintf(`{"id": "%d"}`, id))
+		}
+		fmt.Fprintf(w, `{"audit_log_entries": [%s]}`, strings.Join(entries, ","))
+	})
+	defer closeServer()
+
+	it := s.GuildAuditLogIterator("guild", &GuildAuditLogParams{AfterID: "3", Limit: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Entry().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator returned error: %v", err)
+	}
+
+	if want := []string{"10", "9", "8", "7", "6", "5", "4"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got entries %v, want %v", ids, want)
+	}
+	if want := []string{"", "9", "7", "5"}; !reflect.DeepEqual(befores, want) {
+		t.Errorf("got before parameters %v, want %v", befores, want)
+	}
+}
@@ -0,0 +1,288 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains functions to declaratively sync application commands
+// with the commands registered on Discord.
+
+package discordgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrApplicationCommandDuplicate is returned when the desired commands of
+// a sync contain several commands with the same type and name.
+var ErrApplicationCommandDuplicate = errors.New("duplicate application command")
+
+// ApplicationCommandChangeType is the kind of change a sync applies to a
+// command.
+type ApplicationCommandChangeType int
+
+// Valid ApplicationCommandChangeType values
+const (
+	ApplicationCommandChangeCreate ApplicationCommandChangeType = iota + 1
+	ApplicationCommandChangeEdit
+	ApplicationCommandChangeDelete
+)
+
+func (t ApplicationCommandChangeType) String() string {
+	switch t {
+	case ApplicationCommandChangeCreate:
+		return "create"
+	case ApplicationCommandChangeEdit:
+		return "edit"
+	case ApplicationCommandChangeDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("ApplicationCommandChangeType(%d)", t)
+}
+
+// ApplicationCommandChange is a change needed to turn the registered
+// commands into the desired ones.
+type ApplicationCommandChange struct {
+	Type ApplicationCommandChangeType
+
+	// The registered command, nil for creates.
+	Registered *ApplicationCommand
+
+	// The desired command, nil for deletes.
+	Desired *ApplicationCommand
+
+	// The JSON names of the fields which differ, for edits.
+	Fields []string
+}
+
+// String returns a line of a diff report, e.g. `edit "ban": description, options`.
+func (c *ApplicationCommandChange) String() string {
+	cmd := c.Desired
+	if cmd == nil {
+		cmd = c.Registered
+	}
+
+	s := fmt.Sprintf("%s %q", c.Type, cmd.Name)
+	if t := normalizedCommandType(cmd.Type); t != ChatApplicationCommand {
+		s += fmt.Sprintf(" (%s)", applicationCommandTypeName(t))
+	}
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// ApplicationCommandDiff compares the registered commands with the desired
+// ones and returns the changes ApplicationCommandSync would apply, without
+// applying them. Commands are matched by type and name. Fields assigned by
+// Discord, like IDs and versions, are ignored, and unset fields compare
+// equal to their defaults.
+// appID    : The application ID.
+// guildID  : Guild ID to diff guild-specific commands. If empty - diffs global commands.
+// commands : The desired commands.
+func (s *Session) ApplicationCommandDiff(appID, guildID string, commands []*ApplicationCommand) (changes []*ApplicationCommandChange, err error) {
+	registered, err := s.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return
+	}
+
+	return DiffApplicationCommands(registered, commands)
+}
+
+// ApplicationCommandSync makes the registered commands match the desired
+// ones, only creating, editing and deleting the commands which changed.
+// Unlike ApplicationCommandBulkOverwrite it does not touch unchanged
+// commands, which keeps their IDs and the daily command create limit.
+// It returns the applied changes; on error the changes applied before it.
+// appID    : The application ID.
+// guildID  : Guild ID to sync guild-specific commands. If empty - syncs global commands.
+// commands : The desired commands.
+func (s *Session) ApplicationCommandSync(appID, guildID string, commands []*ApplicationCommand, options ...RequestOption) (applied []*ApplicationCommandChange, err error) {
+	changes, err := s.ApplicationCommandDiff(appID, guildID, commands)
+	if err != nil {
+		return
+	}
+
+	for _, c := range changes {
+		switch c.Type {
+		case ApplicationCommandChangeCreate:
+			_, err = s.ApplicationCommandCreate(appID, guildID, c.Desired, options...)
+		case ApplicationCommandChangeEdit:
+			_, err = s.ApplicationCommandEdit(appID, guildID, c.Registered.ID, c.Desired, options...)
+		case ApplicationCommandChangeDelete:
+			err = s.ApplicationCommandDelete(appID, guildID, c.Registered.ID, options...)
+		}
+		if err != nil {
+			return
+		}
+		applied = append(applied, c)
+	}
+	return
+}
+
+// DiffApplicationCommands returns the changes needed to turn the
+// registered commands into the desired ones, like ApplicationCommandDiff.
+// Deletes come first to free up command slots, then edits and creates.
+func DiffApplicationCommands(registered, desired []*ApplicationCommand) (changes []*ApplicationCommandChange, err error) {
+	type key struct {
+		t    ApplicationCommandType
+		name string
+	}
+
+	want := make(map[key]*ApplicationCommand, len(desired))
+	for _, cmd := range desired {
+		k := key{normalizedCommandType(cmd.Type), cmd.Name}
+		if _, ok := want[k]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrApplicationCommandDuplicate, cmd.Name)
+		}
+		want[k] = cmd
+	}
+
+	var edits []*ApplicationCommandChange
+	have := make(map[key]bool, len(registered))
+	for _, cmd := range registered {
+		k := key{normalizedCommandType(cmd.Type), cmd.Name}
+		have[k] = true
+
+		d, ok := want[k]
+		if !ok {
+			changes = append(changes, &ApplicationCommandChange{Type: ApplicationCommandChangeDelete, Registered: cmd})
+			continue
+		}
+
+		fields, err := applicationCommandDiffFields(cmd, d)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			edits = append(edits, &ApplicationCommandChange{Type: ApplicationCommandChangeEdit, Registered: cmd, Desired: d, Fields: fields})
+		}
+	}
+	changes = append(changes, edits...)
+
+	for _, cmd := range desired {
+		if !have[key{normalizedCommandType(cmd.Type), cmd.Name}] {
+			changes = append(changes, &ApplicationCommandChange{Type: ApplicationCommandChangeCreate, Desired: cmd})
+		}
+	}
+	return
+}
+
+// applicationCommandDiffFields returns the sorted JSON names of the fields
+// which differ between the normalized commands a and b.
+func applicationCommandDiffFields(a, b *ApplicationCommand) ([]string, error) {
+	na, err := normalizeApplicationCommand(a)
+	if err != nil {
+		return nil, err
+	}
+	nb, err := normalizeApplicationCommand(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for name, v := range na {
+		if !bytes.Equal(v, nb[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range nb {
+		if _, ok := na[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// normalizeApplicationCommand returns the JSON fields of a command which
+// are set by the user, with unset fields set to their defaults.
+// Comparing JSON also makes choice values unmarshaled as float64 equal to
+// the integers they were created from.
+func normalizeApplicationCommand(cmd *ApplicationCommand) (map[string]json.RawMessage, error) {
+	// Round trip through JSON to not modify cmd.
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var c ApplicationCommand
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	c.ID, c.ApplicationID, c.Version = "", "", ""
+	c.Type = normalizedCommandType(c.Type)
+	if c.DMPermission == nil {
+		c.DMPermission = new(bool)
+		*c.DMPermission = true
+	}
+	if c.NSFW != nil && !*c.NSFW {
+		c.NSFW = nil
+	}
+	c.NameLocalizations = normalizeLocalizations(c.NameLocalizations)
+	c.DescriptionLocalizations = normalizeLocalizations(c.DescriptionLocalizations)
+	c.Options = normalizeApplicationCommandOptions(c.Options)
+
+	if data, err = json.Marshal(&c); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+func normalizeApplicationCommandOptions(options []*ApplicationCommandOption) []*ApplicationCommandOption {
+	if len(options) == 0 {
+		return nil
+	}
+	for _, o := range options {
+		if len(o.ChannelTypes) == 0 {
+			o.ChannelTypes = nil
+		}
+		sort.Slice(o.ChannelTypes, func(i, j int) bool { return o.ChannelTypes[i] < o.ChannelTypes[j] })
+		if len(o.Choices) == 0 {
+			o.Choices = nil
+		}
+		for _, c := range o.Choices {
+			c.NameLocalizations = normalizeLocalizations(c.NameLocalizations)
+		}
+		o.NameLocalizations = normalizeLocalizations(o.NameLocalizations)
+		o.DescriptionLocalizations = normalizeLocalizations(o.DescriptionLocalizations)
+		o.Options = normalizeApplicationCommandOptions(o.Options)
+	}
+	return options
+}
+
+func normalizeLocalizations(l map[Locale]string) map[Locale]string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// normalizedCommandType returns the type of a command, which defaults to
+// ChatApplicationCommand.
+func normalizedCommandType(t ApplicationCommandType) ApplicationCommandType {
+	if t == 0 {
+		return ChatApplicationCommand
+	}
+	return t
+}
+
+func applicationCommandTypeName(t ApplicationCommandType) string {
+	switch t {
+	case ChatApplicationCommand:
+		return "chat"
+	case UserApplicationCommand:
+		return "user"
+	case MessageApplicationCommand:
+		return "message"
+	}
+	return fmt.Sprintf("type %d", t)
+}
@@ -0,0 +1,79 @@
+package discordgo
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDiffApplicationCommands(t *testing.T) {
+	registered := []*ApplicationCommand{
+		{ID: "1", ApplicationID: "app", Version: "1", Type: ChatApplicationCommand, Name: "ping", Description: "Ping",
+			Options: []*ApplicationCommandOption{{
+				Type: ApplicationCommandOptionInteger, Name: "n", Description: "N",
+				Choices: []*ApplicationCommandOptionChoice{{Name: "one", Value: float64(1)}},
+			}}},
+		{ID: "2", Type: ChatApplicationCommand, Name: "ban", Description: "Ban"},
+		{ID: "3", Type: UserApplicationCommand, Name: "old"},
+	}
+
+	dm := true
+	desired := []*ApplicationCommand{
+		{Name: "ping", Description: "Ping", DMPermission: &dm, NameLocalizations: map[Locale]string{},
+			Options: []*ApplicationCommandOption{{
+				Type: ApplicationCommandOptionInteger, Name: "n", Description: "N", ChannelTypes: []ChannelType{},
+				Choices: []*ApplicationCommandOptionChoice{{Name: "one", Value: 1}},
+			}}},
+		{Name: "ban", Description: "Ban a member"},
+		{Type: MessageApplicationCommand, Name: "report"},
+	}
+
+	changes, err := DiffApplicationCommands(registered, desired)
+	if err != nil {
+		t.Fatalf("DiffApplicationCommands returned error: %v", err)
+	}
+	var report []string
+	for _, c := range changes {
+		report = append(report, c.String())
+	}
+	want := []string{`delete "old" (user)`, `edit "ban": description`, `create "report" (message)`}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("got changes %q, want %q", report, want)
+	}
+
+	if _, err := DiffApplicationCommands(nil, []*ApplicationCommand{{Name: "a"}, {Type: ChatApplicationCommand, Name: "a"}}); err == nil {
+		t.Errorf("duplicate commands did not return an error")
+	}
+}
+
+func TestApplicationCommandSync(t *testing.T) {
+	var requests []string
+	s, closeServer := newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id": "1", "type": 1, "name": "ping", "description": "Ping", "dm_permission": true}, {"id": "2", "type": 1, "name": "old", "description": "Old"}]`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{"id": "3"}`))
+		}
+	})
+	defer closeServer()
+
+	applied, err := s.ApplicationCommandSync("app", "guild", []*ApplicationCommand{
+		{Name: "ping", Description: "Ping"},
+		{Name: "new", Description: "New"},
+	})
+	if err != nil {
+		t.Fatalf("ApplicationCommandSync returned error: %v", err)
+	}
+	want := []string{
+		"GET /api/v8/applications/app/guilds/guild/commands",
+		"DELETE /api/v8/applications/app/guilds/guild/commands/2",
+		"POST /api/v8/applications/app/guilds/guild/commands",
+	}
+	if !reflect.DeepEqual(requests, want) || len(applied) != 2 {
+		t.Errorf("got requests %q and %d changes, want %q", requests, len(applied), want)
+	}
+}
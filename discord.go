@@ -61,6 +61,7 @@ func New(args ...interface{}) (s *Session, err error) {
 		ShardID:                0,
 		ShardCount:             1,
 		MaxRestRetries:         3,
+		GuildsReadyTimeout:     15 * time.Second,
 		Client:                 &http.Client{Timeout: (20 * time.Second)},
 		UserAgent:              "DiscordBot (https://github.com/bwmarrin/discordgo, v" + VERSION + ")",
 		sequence:               new(int64),
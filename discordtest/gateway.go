@@ -0,0 +1,328 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the fake gateway of the Server.
+
+package discordtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/leejavier/discordgo"
+)
+
+// ResumeEvents is the number of events the gateway keeps per session, to
+// send them again when the session is resumed.
+const ResumeEvents = 1000
+
+var upgrader = websocket.Upgrader{}
+
+type gatewayPayload struct {
+	Operation int             `json:"op"`
+	Sequence  int64           `json:"s,omitempty"`
+	Type      string          `json:"t,omitempty"`
+	Data      json.RawMessage `json:"d"`
+}
+
+// A gatewayConn is a websocket connection to the gateway.
+type gatewayConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	// The session of the connection, guarded by Server.gatewayMu.
+	session *gatewaySession
+}
+
+func (c *gatewayConn) write(p *gatewayPayload) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.ws.WriteJSON(p)
+}
+
+// close closes the connection with a close code.
+func (c *gatewayConn) close(code int, text string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(time.Second))
+	c.ws.Close()
+}
+
+// A gatewaySession is an identified session, which keeps its events for
+// resumes while it is disconnected.
+type gatewaySession struct {
+	id       string
+	sequence int64
+	events   []*gatewayPayload
+	conn     *gatewayConn
+}
+
+// Dispatch emits an event to all gateway sessions. The events of
+// disconnected sessions are sent when they resume.
+// eventType : The name of the event, e.g. "MESSAGE_CREATE".
+// data      : The data of the event, marshalled to JSON.
+func (s *Server) Dispatch(eventType string, data interface{}) {
+	raw, ok := data.(json.RawMessage)
+	if !ok {
+		raw = marshal(data)
+	}
+
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	for _, gs := range s.sessions {
+		s.dispatchTo(gs, eventType, raw)
+	}
+}
+
+// Disconnect closes all gateway connections with a close code. Their
+// sessions can be resumed.
+// code : The close code, e.g. int(discordgo.GatewayCloseUnknownError).
+func (s *Server) Disconnect(code int) {
+	for _, c := range s.gatewayConns() {
+		c.close(code, "")
+	}
+}
+
+// Reconnect asks all connected sessions to reconnect and resume, with an
+// Op 7 Reconnect.
+func (s *Server) Reconnect() {
+	for _, c := range s.gatewayConns() {
+		c.write(&gatewayPayload{Operation: 7})
+	}
+}
+
+// InvalidateSessions ends all gateway sessions, so they can not be
+// resumed anymore. Connected sessions get an Op 9 Invalid Session.
+func (s *Server) InvalidateSessions() {
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	for id, gs := range s.sessions {
+		if gs.conn != nil {
+			gs.conn.session = nil
+			gs.conn.write(&gatewayPayload{Operation: 9, Data: json.RawMessage("false")})
+		}
+		delete(s.sessions, id)
+	}
+}
+
+// Connections returns the number of open gateway connections.
+func (s *Server) Connections() int {
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	return len(s.conns)
+}
+
+func (s *Server) gatewayConns() []*gatewayConn {
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	conns := make([]*gatewayConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+// serveGateway serves a gateway connection: it sends HELLO, and answers
+// heartbeats, identifies and resumes until the connection is closed.
+func (s *Server) serveGateway(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &gatewayConn{ws: ws}
+	s.gatewayMu.Lock()
+	s.conns[c] = true
+	s.gatewayMu.Unlock()
+	defer s.closeGatewayConn(c)
+
+	interval := s.HeartbeatInterval
+	if interval == 0 {
+		interval = DefaultHeartbeatInterval
+	}
+	hello := marshal(map[string]int64{"heartbeat_interval": int64(interval / time.Millisecond)})
+	if c.write(&gatewayPayload{Operation: 10, Data: hello}) != nil {
+		return
+	}
+
+	for {
+		var p gatewayPayload
+		if err := ws.ReadJSON(&p); err != nil {
+			// Discord ends the session when it is closed normally.
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.endGatewaySession(c)
+			}
+			return
+		}
+
+		s.gatewayMu.Lock()
+		s.commands = append(s.commands, Command{Operation: p.Operation, Data: p.Data})
+		s.gatewayMu.Unlock()
+
+		switch p.Operation {
+		case 1:
+			c.write(&gatewayPayload{Operation: 11})
+		case 2:
+			if !s.identify(c, p.Data) {
+				return
+			}
+		case 6:
+			if !s.resume(c, p.Data) {
+				return
+			}
+		case 3, 4, 8:
+			// Presence and voice state updates and guild member requests
+			// are only recorded.
+		default:
+			c.close(int(discordgo.GatewayCloseUnknownOpcode), "Unknown opcode.")
+			return
+		}
+	}
+}
+
+// identify starts a new session on a connection and sends READY and the
+// guilds. It returns false when it closed the connection.
+func (s *Server) identify(c *gatewayConn, data json.RawMessage) bool {
+	var d struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(data, &d)
+	if s.Token != "" && d.Token != s.Token {
+		c.close(int(discordgo.GatewayCloseAuthenticationFailed), "Authentication failed.")
+		return false
+	}
+
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	if c.session != nil {
+		c.close(int(discordgo.GatewayCloseAlreadyAuthenticated), "Already authenticated.")
+		return false
+	}
+
+	gs := &gatewaySession{id: s.newID(), conn: c}
+	c.session = gs
+	s.sessions[gs.id] = gs
+
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.guilds))
+	for id := range s.guilds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	unavailable := make([]*discordgo.Guild, len(ids))
+	guilds := make([]json.RawMessage, len(ids))
+	for i, id := range ids {
+		unavailable[i] = &discordgo.Guild{ID: id, Unavailable: true}
+		guilds[i] = marshal(s.guilds[id])
+	}
+	user := *s.User
+	s.mu.Unlock()
+
+	version, _ := strconv.Atoi(discordgo.APIVersion)
+	s.dispatchTo(gs, "READY", marshal(&discordgo.Ready{
+		Version:          version,
+		SessionID:        gs.id,
+		ResumeGatewayURL: s.gatewayURL(),
+		User:             &user,
+		Guilds:           unavailable,
+	}))
+	for _, g := range guilds {
+		s.dispatchTo(gs, "GUILD_CREATE", g)
+	}
+	return true
+}
+
+// resume resumes a session on a connection: it sends the events after the
+// sequence of the client, and RESUMED. It sends an Op 9 Invalid Session
+// when the session can not be resumed, and returns false when it closed
+// the connection.
+func (s *Server) resume(c *gatewayConn, data json.RawMessage) bool {
+	var d struct {
+		Token     string `json:"token"`
+		SessionID string `json:"session_id"`
+		Sequence  int64  `json:"seq"`
+	}
+	json.Unmarshal(data, &d)
+	if s.Token != "" && d.Token != s.Token {
+		c.close(int(discordgo.GatewayCloseAuthenticationFailed), "Authentication failed.")
+		return false
+	}
+
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	gs, ok := s.sessions[d.SessionID]
+	if !ok || d.Sequence > gs.sequence || (len(gs.events) > 0 && d.Sequence < gs.events[0].Sequence-1) {
+		c.write(&gatewayPayload{Operation: 9, Data: json.RawMessage("false")})
+		return true
+	}
+
+	if gs.conn != nil && gs.conn != c {
+		gs.conn.session = nil
+	}
+	gs.conn = c
+	c.session = gs
+
+	for _, p := range gs.events {
+		if p.Sequence > d.Sequence {
+			c.write(p)
+		}
+	}
+	s.dispatchTo(gs, "RESUMED", json.RawMessage("{}"))
+	return true
+}
+
+// dispatchTo sends an event to a session, or keeps it for its resume. The
+// gateway must be locked.
+func (s *Server) dispatchTo(gs *gatewaySession, eventType string, data json.RawMessage) {
+	gs.sequence++
+	p := &gatewayPayload{Operation: 0, Sequence: gs.sequence, Type: eventType, Data: data}
+
+	gs.events = append(gs.events, p)
+	if len(gs.events) > ResumeEvents {
+		gs.events = gs.events[len(gs.events)-ResumeEvents:]
+	}
+	if gs.conn != nil {
+		gs.conn.write(p)
+	}
+}
+
+// closeGatewayConn forgets a closed connection. Its session stays
+// resumable.
+func (s *Server) closeGatewayConn(c *gatewayConn) {
+	s.gatewayMu.Lock()
+	delete(s.conns, c)
+	if c.session != nil && c.session.conn == c {
+		c.session.conn = nil
+	}
+	s.gatewayMu.Unlock()
+
+	c.ws.Close()
+}
+
+// endGatewaySession ends the session of a connection.
+func (s *Server) endGatewaySession(c *gatewayConn) {
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	if c.session != nil {
+		delete(s.sessions, c.session.id)
+		c.session = nil
+	}
+}
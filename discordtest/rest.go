@@ -0,0 +1,430 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the fake REST API of the Server.
+
+package discordtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leejavier/discordgo"
+)
+
+// A restError is an error response of the REST API.
+type restError struct {
+	status  int
+	code    int
+	message string
+}
+
+func (e *restError) Error() string {
+	return e.message
+}
+
+var (
+	errUnauthorized   = &restError{http.StatusUnauthorized, 0, "401: Unauthorized"}
+	errNotFound       = &restError{http.StatusNotFound, 0, "404: Not Found"}
+	errBadRequest     = &restError{http.StatusBadRequest, 50035, "Invalid Form Body"}
+	errUnknownChannel = &restError{http.StatusNotFound, 10003, "Unknown Channel"}
+	errUnknownGuild   = &restError{http.StatusNotFound, 10004, "Unknown Guild"}
+	errUnknownMessage = &restError{http.StatusNotFound, 10008, "Unknown Message"}
+)
+
+// A bucket is the rate limit of a route.
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// ForceRateLimit answers the next REST requests with a 429 Too Many
+// Requests, whether or not RateLimit is set.
+// n          : The number of requests to answer with a 429.
+// retryAfter : The retry_after of the 429 responses.
+func (s *Server) ForceRateLimit(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.forced = append(s.forced, retryAfter)
+	}
+}
+
+// serveREST serves a request to the REST API.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	// Strip the /api/v8/ prefix.
+	path := strings.TrimPrefix(r.URL.Path, "/api/")
+	if strings.HasPrefix(path, "v") {
+		if i := strings.IndexByte(path, '/'); i >= 0 {
+			path = path[i+1:]
+		}
+	}
+	route := strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+	limited := s.rateLimit(w, r.Method+" "+routeKey(route))
+	s.mu.Unlock()
+	if limited {
+		return
+	}
+
+	// Like Discord, GET /gateway does not need authorization.
+	if s.Token != "" && r.Header.Get("Authorization") != s.Token && path != "gateway" {
+		writeError(w, errUnauthorized)
+		return
+	}
+
+	v, err := s.route(r, route)
+	switch {
+	case err != nil:
+		if e, ok := err.(*restError); ok {
+			writeError(w, e)
+		} else {
+			writeError(w, &restError{http.StatusBadRequest, 50035, err.Error()})
+		}
+	case v == nil:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(v)
+	}
+}
+
+// route calls the handler of a route, and returns the JSON response, or
+// nil for 204 No Content.
+func (s *Server) route(r *http.Request, route []string) (json.RawMessage, error) {
+	n := len(route)
+	switch {
+	case n == 1 && route[0] == "gateway" && r.Method == "GET":
+		return marshal(map[string]string{"url": s.gatewayURL()}), nil
+	case n == 2 && route[0] == "gateway" && route[1] == "bot" && r.Method == "GET":
+		return marshal(&discordgo.GatewayBotResponse{
+			URL:               s.gatewayURL(),
+			Shards:            1,
+			SessionStartLimit: discordgo.SessionInformation{Total: 1000, Remaining: 1000, ResetAfter: 86400000, MaxConcurrency: 1},
+		}), nil
+	case n == 2 && route[0] == "users" && route[1] == "@me" && r.Method == "GET":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return marshal(s.User), nil
+	case n == 2 && route[0] == "guilds" && r.Method == "GET":
+		return s.getGuild(route[1])
+	case n == 3 && route[0] == "guilds" && route[2] == "channels":
+		switch r.Method {
+		case "GET":
+			return s.getGuildChannels(route[1])
+		case "POST":
+			return s.postGuildChannel(r, route[1])
+		}
+	case n == 2 && route[0] == "channels" && r.Method == "GET":
+		return s.getChannel(route[1])
+	case n == 3 && route[0] == "channels" && route[2] == "messages":
+		switch r.Method {
+		case "GET":
+			return s.getMessages(r, route[1])
+		case "POST":
+			return s.postMessage(r, route[1])
+		}
+	case n == 4 && route[0] == "channels" && route[2] == "messages":
+		switch r.Method {
+		case "GET":
+			return s.getMessage(route[1], route[3])
+		case "PATCH":
+			return s.patchMessage(r, route[1], route[3])
+		case "DELETE":
+			return nil, s.deleteMessage(route[1], route[3])
+		}
+	}
+	return nil, errNotFound
+}
+
+func (s *Server) getGuild(guildID string) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		return nil, errUnknownGuild
+	}
+	return marshal(g), nil
+}
+
+func (s *Server) getGuildChannels(guildID string) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		return nil, errUnknownGuild
+	}
+	channels := g.Channels
+	if channels == nil {
+		channels = []*discordgo.Channel{}
+	}
+	return marshal(channels), nil
+}
+
+func (s *Server) postGuildChannel(r *http.Request, guildID string) (json.RawMessage, error) {
+	var data discordgo.GuildChannelCreateData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil || data.Name == "" {
+		return nil, errBadRequest
+	}
+
+	s.mu.Lock()
+	g, ok := s.guilds[guildID]
+	if !ok {
+		s.mu.Unlock()
+		return nil, errUnknownGuild
+	}
+	c := &discordgo.Channel{
+		ID:       s.newID(),
+		GuildID:  guildID,
+		Name:     data.Name,
+		Type:     data.Type,
+		Topic:    data.Topic,
+		ParentID: data.ParentID,
+		NSFW:     data.NSFW,
+	}
+	g.Channels = append(g.Channels, c)
+	s.channels[c.ID] = c
+	raw := marshal(c)
+	s.mu.Unlock()
+
+	s.Dispatch("CHANNEL_CREATE", raw)
+	return raw, nil
+}
+
+func (s *Server) getChannel(channelID string) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.channels[channelID]
+	if !ok {
+		return nil, errUnknownChannel
+	}
+	return marshal(c), nil
+}
+
+// getMessages returns the messages of a channel newest first, like
+// Discord, limited by the limit, before and after query parameters.
+func (s *Server) getMessages(r *http.Request, channelID string) (json.RawMessage, error) {
+	q := r.URL.Query()
+	limit := 50
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	before, after := q.Get("before"), q.Get("after")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.channels[channelID]; !ok {
+		return nil, errUnknownChannel
+	}
+
+	messages := []*discordgo.Message{}
+	all := s.messages[channelID]
+	for i := len(all) - 1; i >= 0 && len(messages) < limit; i-- {
+		m := all[i]
+		if (before == "" || idLess(m.ID, before)) && (after == "" || idLess(after, m.ID)) {
+			messages = append(messages, m)
+		}
+	}
+	return marshal(messages), nil
+}
+
+func (s *Server) getMessage(channelID, messageID string) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, _, err := s.message(channelID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(m), nil
+}
+
+func (s *Server) postMessage(r *http.Request, channelID string) (json.RawMessage, error) {
+	var data discordgo.MessageSend
+	if err := decodeMessage(r, &data); err != nil {
+		return nil, errBadRequest
+	}
+	if data.Content == "" && len(data.Embeds) == 0 {
+		return nil, &restError{http.StatusBadRequest, 50006, "Cannot send an empty message"}
+	}
+
+	s.mu.Lock()
+	author := *s.User
+	s.mu.Unlock()
+
+	m, err := s.createMessage(channelID, &author, &data)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(m), nil
+}
+
+func (s *Server) patchMessage(r *http.Request, channelID, messageID string) (json.RawMessage, error) {
+	var data struct {
+		Content *string                   `json:"content"`
+		Embeds  []*discordgo.MessageEmbed `json:"embeds"`
+	}
+	if err := decodeMessage(r, &data); err != nil {
+		return nil, errBadRequest
+	}
+
+	s.mu.Lock()
+	m, _, err := s.message(channelID, messageID)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	if data.Content != nil {
+		m.Content = *data.Content
+	}
+	if data.Embeds != nil {
+		m.Embeds = data.Embeds
+	}
+	m.EditedTimestamp = discordgo.Timestamp(time.Now().UTC().Format(time.RFC3339Nano))
+	raw := marshal(m)
+	s.mu.Unlock()
+
+	s.Dispatch("MESSAGE_UPDATE", raw)
+	return raw, nil
+}
+
+func (s *Server) deleteMessage(channelID, messageID string) error {
+	s.mu.Lock()
+	m, i, err := s.message(channelID, messageID)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	messages := s.messages[channelID]
+	s.messages[channelID] = append(messages[:i:i], messages[i+1:]...)
+	s.mu.Unlock()
+
+	s.Dispatch("MESSAGE_DELETE", map[string]string{"id": m.ID, "channel_id": m.ChannelID, "guild_id": m.GuildID})
+	return nil
+}
+
+// message returns a message and its index. The server must be locked.
+func (s *Server) message(channelID, messageID string) (*discordgo.Message, int, error) {
+	if _, ok := s.channels[channelID]; !ok {
+		return nil, 0, errUnknownChannel
+	}
+	for i, m := range s.messages[channelID] {
+		if m.ID == messageID {
+			return m, i, nil
+		}
+	}
+	return nil, 0, errUnknownMessage
+}
+
+// rateLimit sets the rate limit headers of a route, and answers with a 429
+// when the route is rate limited. The server must be locked.
+func (s *Server) rateLimit(w http.ResponseWriter, key string) (limited bool) {
+	if len(s.forced) > 0 {
+		retryAfter := s.forced[0]
+		s.forced = s.forced[1:]
+		writeTooManyRequests(w, key, retryAfter)
+		return true
+	}
+	if s.RateLimit <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.reset) {
+		b = &bucket{remaining: s.RateLimit, reset: now.Add(s.RateLimitWindow)}
+		s.buckets[key] = b
+	}
+
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.RateLimit))
+	h.Set("X-RateLimit-Bucket", bucketHash(key))
+	h.Set("X-RateLimit-Reset", formatSeconds(time.Duration(b.reset.UnixNano())))
+	h.Set("X-RateLimit-Reset-After", formatSeconds(b.reset.Sub(now)))
+
+	if b.remaining <= 0 {
+		h.Set("X-RateLimit-Remaining", "0")
+		writeTooManyRequests(w, key, b.reset.Sub(now))
+		return true
+	}
+	b.remaining--
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return false
+}
+
+func writeTooManyRequests(w http.ResponseWriter, key string, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write([]byte(fmt.Sprintf(`{"message":"You are being rate limited.","retry_after":%s,"global":false,"bucket":%q}`,
+		formatSeconds(retryAfter), bucketHash(key))))
+}
+
+func writeError(w http.ResponseWriter, e *restError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	w.Write(marshal(&discordgo.APIErrorMessage{Code: e.code, Message: e.message}))
+}
+
+// decodeMessage decodes the JSON body of a message request, or the
+// payload_json field of a multipart request with files.
+func decodeMessage(r *http.Request, v interface{}) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(r.FormValue("payload_json")), v)
+	}
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// routeKey returns the rate limit key of a route: its path with the IDs
+// replaced, except the major parameters channel and guild ID.
+func routeKey(route []string) string {
+	key := make([]string, len(route))
+	for i, part := range route {
+		if _, err := strconv.ParseUint(part, 10, 64); err == nil && (i != 1 || (route[0] != "channels" && route[0] != "guilds")) {
+			part = "{id}"
+		}
+		key[i] = part
+	}
+	return strings.Join(key, "/")
+}
+
+func bucketHash(key string) string {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// formatSeconds formats a duration as seconds with milliseconds, like the
+// rate limit headers of Discord.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// idLess reports whether snowflake a is older than snowflake b.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
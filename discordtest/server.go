@@ -0,0 +1,296 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package discordtest provides a fake Discord API for offline tests: a
+// local gateway and REST server with in-memory guilds, channels and
+// messages, which a discordgo.Session can connect to.
+//
+//	srv := discordtest.NewServer()
+//	defer srv.Close()
+//	guild := srv.AddGuild("Guild")
+//	channel := srv.AddChannel(guild.ID, "general")
+//
+//	s, _ := srv.Session()
+//	s.AddHandler(func(s *discordgo.Session, m *discordgo.MessageCreate) { ... })
+//	s.Open()
+//	srv.AddMessage(channel.ID, &discordgo.User{ID: "1", Username: "user"}, "!ping")
+package discordtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/leejavier/discordgo"
+)
+
+// DefaultHeartbeatInterval is the heartbeat interval sent in HELLO when
+// Server.HeartbeatInterval is zero.
+const DefaultHeartbeatInterval = 41250 * time.Millisecond
+
+// A Server is a fake Discord API. Its REST server serves the current user,
+// guilds, channels and messages, and emits the matching dispatch events
+// on its gateway. Its fields must be set before sessions connect.
+type Server struct {
+	// The token sessions must use, e.g. "Bot token". When empty, any
+	// token is accepted.
+	Token string
+
+	// The heartbeat interval sent in HELLO, DefaultHeartbeatInterval when
+	// zero.
+	HeartbeatInterval time.Duration
+
+	// The number of requests each route allows per RateLimitWindow. The
+	// rate limit headers are only sent when RateLimit is not zero.
+	RateLimit       int
+	RateLimitWindow time.Duration
+
+	// The URL of the server, e.g. http://127.0.0.1:1234.
+	URL string
+
+	// The bot user of the sessions.
+	User *discordgo.User
+
+	server *httptest.Server
+	lastID int64
+
+	// The in-memory data, guarded by mu.
+	mu       sync.Mutex
+	guilds   map[string]*discordgo.Guild
+	channels map[string]*discordgo.Channel
+	messages map[string][]*discordgo.Message
+	buckets  map[string]*bucket
+	forced   []time.Duration
+	requests []Request
+
+	// The gateway connections and sessions, guarded by gatewayMu.
+	gatewayMu sync.Mutex
+	conns     map[*gatewayConn]bool
+	sessions  map[string]*gatewaySession
+	commands  []Command
+}
+
+// A Request is a REST request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// A Command is a gateway payload received by a Server, e.g. an identify.
+type Command struct {
+	Operation int
+	Data      json.RawMessage
+}
+
+// NewServer starts and returns a Server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		lastID:   100000000000000000,
+		guilds:   make(map[string]*discordgo.Guild),
+		channels: make(map[string]*discordgo.Channel),
+		messages: make(map[string][]*discordgo.Message),
+		buckets:  make(map[string]*bucket),
+		conns:    make(map[*gatewayConn]bool),
+		sessions: make(map[string]*gatewaySession),
+	}
+	s.User = &discordgo.User{ID: s.newID(), Username: "bot", Discriminator: "0000", Bot: true}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close closes the gateway connections and stops the server.
+func (s *Server) Close() {
+	s.Disconnect(websocket.CloseGoingAway)
+	s.server.Close()
+}
+
+// Client returns a http.Client which sends the requests to Discord to the
+// server instead.
+func (s *Server) Client() *http.Client {
+	u, _ := url.Parse(s.URL)
+	return &http.Client{Transport: &transport{url: u}, Timeout: 20 * time.Second}
+}
+
+// Session returns a discordgo.Session which uses the server for its REST
+// requests and gateway connection. Its identifies are not rate limited.
+func (s *Server) Session() (*discordgo.Session, error) {
+	token := s.Token
+	if token == "" {
+		token = "Bot token"
+	}
+
+	session, err := discordgo.New(token)
+	if err != nil {
+		return nil, err
+	}
+	session.Client = s.Client()
+	session.IdentifyLimiter = noIdentifyLimiter{}
+	return session, nil
+}
+
+// Requests returns the REST requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Commands returns the gateway payloads received so far.
+func (s *Server) Commands() []Command {
+	s.gatewayMu.Lock()
+	defer s.gatewayMu.Unlock()
+
+	return append([]Command(nil), s.commands...)
+}
+
+// AddGuild adds a guild and emits GUILD_CREATE.
+// name : The name of the guild.
+func (s *Server) AddGuild(name string) *discordgo.Guild {
+	g := &discordgo.Guild{ID: s.newID(), Name: name, OwnerID: s.User.ID, MemberCount: 1}
+
+	s.mu.Lock()
+	s.guilds[g.ID] = g
+	data := marshal(g)
+	s.mu.Unlock()
+
+	s.Dispatch("GUILD_CREATE", data)
+	return copyGuild(g)
+}
+
+// AddChannel adds a text channel to a guild and emits CHANNEL_CREATE.
+// guildID : The ID of the guild.
+// name    : The name of the channel.
+func (s *Server) AddChannel(guildID, name string) *discordgo.Channel {
+	c := &discordgo.Channel{ID: s.newID(), GuildID: guildID, Name: name, Type: discordgo.ChannelTypeGuildText}
+
+	s.mu.Lock()
+	if g, ok := s.guilds[guildID]; ok {
+		g.Channels = append(g.Channels, c)
+	}
+	s.channels[c.ID] = c
+	data := marshal(c)
+	s.mu.Unlock()
+
+	s.Dispatch("CHANNEL_CREATE", data)
+	cp := *c
+	return &cp
+}
+
+// AddMessage adds a message by another user to a channel and emits
+// MESSAGE_CREATE, as if the user sent it.
+// channelID : The ID of the channel.
+// author    : The author of the message.
+// content   : The content of the message.
+func (s *Server) AddMessage(channelID string, author *discordgo.User, content string) (*discordgo.Message, error) {
+	return s.createMessage(channelID, author, &discordgo.MessageSend{Content: content})
+}
+
+// Messages returns the messages of a channel, oldest first.
+// channelID : The ID of the channel.
+func (s *Server) Messages(channelID string) []*discordgo.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]*discordgo.Message, len(s.messages[channelID]))
+	for i, m := range s.messages[channelID] {
+		cp := *m
+		messages[i] = &cp
+	}
+	return messages
+}
+
+// createMessage stores a new message and emits MESSAGE_CREATE.
+func (s *Server) createMessage(channelID string, author *discordgo.User, data *discordgo.MessageSend) (*discordgo.Message, error) {
+	s.mu.Lock()
+	c, ok := s.channels[channelID]
+	if !ok {
+		s.mu.Unlock()
+		return nil, errUnknownChannel
+	}
+	m := &discordgo.Message{
+		ID:        s.newID(),
+		ChannelID: channelID,
+		GuildID:   c.GuildID,
+		Content:   data.Content,
+		Embeds:    data.Embeds,
+		TTS:       data.TTS,
+		Author:    author,
+		Timestamp: discordgo.Timestamp(time.Now().UTC().Format(time.RFC3339Nano)),
+	}
+	s.messages[channelID] = append(s.messages[channelID], m)
+	c.LastMessageID = m.ID
+	raw := marshal(m)
+	s.mu.Unlock()
+
+	s.Dispatch("MESSAGE_CREATE", raw)
+	cp := *m
+	return &cp, nil
+}
+
+// newID returns a new unique, increasing snowflake.
+func (s *Server) newID() string {
+	return strconv.FormatInt(atomic.AddInt64(&s.lastID, 1), 10)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		s.serveREST(w, r)
+		return
+	}
+	s.serveGateway(w, r)
+}
+
+// gatewayURL returns the URL of the gateway of the server.
+func (s *Server) gatewayURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + "/gateway"
+}
+
+// marshal returns the JSON encoding of v, which must be a discordgo type.
+func marshal(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("discordtest: " + err.Error())
+	}
+	return b
+}
+
+func copyGuild(g *discordgo.Guild) *discordgo.Guild {
+	cp := *g
+	cp.Channels = append([]*discordgo.Channel(nil), g.Channels...)
+	return &cp
+}
+
+// transport sends the requests of a client to the server.
+type transport struct {
+	url *url.URL
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.url.Scheme
+	req.URL.Host = t.url.Host
+	req.Host = t.url.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// noIdentifyLimiter lets sessions identify without waiting.
+type noIdentifyLimiter struct{}
+
+func (noIdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	return ctx.Err()
+}
@@ -0,0 +1,244 @@
+package discordtest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/leejavier/discordgo"
+)
+
+// openSession opens a session on the server, and waits until its guilds
+// are ready.
+func openSession(t *testing.T, srv *Server) (*discordgo.Session, chan *discordgo.MessageCreate) {
+	s, err := srv.Session()
+	if err != nil {
+		t.Fatalf("Session: %v", err)
+	}
+
+	ready := make(chan struct{}, 1)
+	s.AddHandler(func(_ *discordgo.Session, _ *discordgo.GuildsReady) { ready <- struct{}{} })
+	messages := make(chan *discordgo.MessageCreate, 10)
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageCreate) { messages <- m })
+
+	if err := s.Open(); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("guilds not ready")
+	}
+	return s, messages
+}
+
+func waitMessage(t *testing.T, messages chan *discordgo.MessageCreate, content string) {
+	select {
+	case m := <-messages:
+		if m.Content != content {
+			t.Errorf("got message %q, want %q", m.Content, content)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("message %q not received", content)
+	}
+}
+
+func countOps(srv *Server, op int) (n int) {
+	for _, c := range srv.Commands() {
+		if c.Operation == op {
+			n++
+		}
+	}
+	return
+}
+
+func TestSession(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Token = "Bot secret"
+	g := srv.AddGuild("Guild")
+	c := srv.AddChannel(g.ID, "general")
+
+	s, messages := openSession(t, srv)
+	defer s.Close()
+
+	if sc, err := s.State.Channel(c.ID); err != nil || sc.Name != "general" {
+		t.Errorf("got channel %+v, %v, want the channel in the state", sc, err)
+	}
+
+	m, err := s.ChannelMessageSend(c.ID, "hello")
+	if err != nil {
+		t.Fatalf("ChannelMessageSend: %v", err)
+	}
+	waitMessage(t, messages, "hello")
+
+	if _, err := srv.AddMessage(c.ID, &discordgo.User{ID: "1", Username: "user"}, "hi"); err != nil {
+		t.Fatalf("AddMessage: %v", err)
+	}
+	waitMessage(t, messages, "hi")
+
+	got, err := s.ChannelMessages(c.ID, 10, "", "", "")
+	if err != nil || len(got) != 2 || got[0].Content != "hi" || got[1].ID != m.ID {
+		t.Errorf("got messages %+v, %v, want hi and hello", got, err)
+	}
+
+	if err := s.ChannelMessageDelete(c.ID, m.ID); err != nil {
+		t.Errorf("ChannelMessageDelete: %v", err)
+	}
+	if n := len(srv.Messages(c.ID)); n != 1 {
+		t.Errorf("got %d messages after the delete, want 1", n)
+	}
+
+	_, err = s.Channel("1")
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil || restErr.Message.Code != 10003 {
+		t.Errorf("unknown channel: got error %v, want code 10003", err)
+	}
+}
+
+func TestResume(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	g := srv.AddGuild("Guild")
+	c := srv.AddChannel(g.ID, "general")
+
+	s, messages := openSession(t, srv)
+	defer s.Close()
+
+	// The message is sent live or on the resume, depending on how fast
+	// the session reconnects, but exactly once either way.
+	srv.Disconnect(int(discordgo.GatewayCloseUnknownError))
+	srv.AddMessage(c.ID, &discordgo.User{ID: "1"}, "missed")
+	waitMessage(t, messages, "missed")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for countOps(srv, 6) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := countOps(srv, 6); n != 1 {
+		t.Errorf("got %d resumes, want 1", n)
+	}
+	if n := countOps(srv, 2); n != 1 {
+		t.Errorf("got %d identifies, want 1", n)
+	}
+
+	select {
+	case m := <-messages:
+		t.Errorf("got message %q twice", m.Content)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestInvalidSession(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddGuild("Guild")
+
+	s, err := srv.Session()
+	if err != nil {
+		t.Fatalf("Session: %v", err)
+	}
+	ready := make(chan string, 2)
+	s.AddHandler(func(_ *discordgo.Session, r *discordgo.Ready) { ready <- r.SessionID })
+	if err := s.Open(); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	first := <-ready
+	srv.InvalidateSessions()
+
+	select {
+	case second := <-ready:
+		if second == first {
+			t.Errorf("got the same session %s after the invalid session", second)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not identify again after an invalid session")
+	}
+	if n := countOps(srv, 2); n != 2 {
+		t.Errorf("got %d identifies, want 2", n)
+	}
+}
+
+func TestAuthenticationFailed(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Token = "Bot secret"
+
+	s, _ := discordgo.New("Bot wrong")
+	s.Client = srv.Client()
+	s.IdentifyLimiter = noIdentifyLimiter{}
+
+	err := s.Open()
+	var closeErr *discordgo.GatewayCloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != discordgo.GatewayCloseAuthenticationFailed {
+		t.Errorf("got error %v, want close code %d", err, discordgo.GatewayCloseAuthenticationFailed)
+	}
+	if err == nil {
+		s.Close()
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	g := srv.AddGuild("Guild")
+	c := srv.AddChannel(g.ID, "general")
+	srv.RateLimit = 1
+	srv.RateLimitWindow = 200 * time.Millisecond
+
+	s, err := srv.Session()
+	if err != nil {
+		t.Fatalf("Session: %v", err)
+	}
+	limited := make(chan *discordgo.RateLimit, 10)
+	s.SyncEvents = true
+	s.AddHandler(func(_ *discordgo.Session, r *discordgo.RateLimit) { limited <- r })
+
+	// The headers of the first response make the session wait for the
+	// reset before the second request.
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := s.ChannelMessageSend(c.ID, "hello"); err != nil {
+			t.Fatalf("ChannelMessageSend: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("second request sent after %v, want it to wait for the reset", elapsed)
+	}
+	if len(limited) != 0 {
+		t.Errorf("got %d 429 responses, want none", len(limited))
+	}
+
+	// A 429 is retried after retry_after.
+	srv.ForceRateLimit(1, 100*time.Millisecond)
+	start = time.Now()
+	if _, err := s.Channel(c.ID); err != nil {
+		t.Fatalf("Channel: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("rate limited request retried after %v, want at least 100ms", elapsed)
+	}
+	if len(limited) != 1 {
+		t.Errorf("got %d RateLimit events, want 1", len(limited))
+	}
+}
+
+func TestRESTErrors(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get("https://discord.com/api/v8/unknown")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var e discordgo.APIErrorMessage
+	if resp.StatusCode != http.StatusNotFound || json.NewDecoder(resp.Body).Decode(&e) != nil || e.Message != "404: Not Found" {
+		t.Errorf("got %s %+v, want 404: Not Found", resp.Status, e)
+	}
+}
@@ -0,0 +1,280 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the EventDispatcher, which runs event handlers on a
+// bounded pool of workers.
+
+package discordgo
+
+import (
+	"hash/fnv"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventOrdering is the order guarantee of an EventDispatcher.
+type EventOrdering int
+
+// Valid EventOrdering values
+const (
+	// EventOrderingNone runs handlers on any free worker, so the handlers
+	// of consecutive events may run concurrently.
+	EventOrderingNone EventOrdering = iota
+
+	// EventOrderingGuild runs the handlers of events of the same guild
+	// one after another, in the order of the events.
+	EventOrderingGuild
+
+	// EventOrderingChannel runs the handlers of events of the same
+	// channel one after another, in the order of the events. Events
+	// without a channel are ordered by guild.
+	EventOrderingChannel
+)
+
+// EventDispatcherStats are the counters of an EventDispatcher.
+type EventDispatcherStats struct {
+	// The number of handler calls waiting in the queues.
+	Queued int64
+
+	// The number of handler calls which returned, including panics.
+	Handled int64
+
+	// The number of handler calls which panicked.
+	Panics int64
+
+	// The number of handler calls which waited for space in a full
+	// queue, and the total time they waited. As the gateway does not
+	// read events while waiting, they indicate handlers which are too
+	// slow for the event rate.
+	Blocked     int64
+	BlockedTime time.Duration
+}
+
+// An EventDispatcher runs event handlers on a fixed number of workers,
+// instead of a goroutine per handler call. Set it as Session.Dispatcher
+// before the session is opened.
+type EventDispatcher struct {
+	// PanicHandler is called when an event handler panics, with the event,
+	// the recovered value and the stack of the panic. When nil, panics
+	// are logged. Either way the worker keeps running.
+	PanicHandler func(s *Session, event interface{}, recovered interface{}, stack []byte)
+
+	ordering EventOrdering
+	queues   []chan dispatchTask
+	next     uint32
+	wg       sync.WaitGroup
+	once     sync.Once
+
+	queued      int64
+	handled     int64
+	panics      int64
+	blocked     int64
+	blockedTime int64
+}
+
+type dispatchTask struct {
+	session *Session
+	handler EventHandler
+	event   interface{}
+}
+
+// NewEventDispatcher returns an EventDispatcher and starts its workers.
+// workers   : The number of handlers which can run at once.
+// queueSize : The number of waiting handler calls, per worker with ordering.
+// ordering  : The order guarantee of the handlers.
+func NewEventDispatcher(workers, queueSize int, ordering EventOrdering) *EventDispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	d := &EventDispatcher{ordering: ordering}
+
+	// With ordering every worker has its own queue, so events with the
+	// same key always run on the same worker.
+	if ordering == EventOrderingNone {
+		d.queues = []chan dispatchTask{make(chan dispatchTask, queueSize)}
+	} else {
+		d.queues = make([]chan dispatchTask, workers)
+		for i := range d.queues {
+			d.queues[i] = make(chan dispatchTask, queueSize)
+		}
+	}
+
+	d.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go d.work(d.queues[i%len(d.queues)])
+	}
+	return d
+}
+
+// Dispatch queues a handler call, waiting while the queue is full.
+func (d *EventDispatcher) Dispatch(s *Session, handler EventHandler, event interface{}) {
+	queue := d.queues[d.queueIndex(event)]
+	task := dispatchTask{s, handler, event}
+	atomic.AddInt64(&d.queued, 1)
+
+	select {
+	case queue <- task:
+		return
+	default:
+	}
+
+	start := time.Now()
+	queue <- task
+	atomic.AddInt64(&d.blocked, 1)
+	atomic.AddInt64(&d.blockedTime, int64(time.Since(start)))
+}
+
+// Stats returns the counters of the dispatcher.
+func (d *EventDispatcher) Stats() EventDispatcherStats {
+	return EventDispatcherStats{
+		Queued:      atomic.LoadInt64(&d.queued),
+		Handled:     atomic.LoadInt64(&d.handled),
+		Panics:      atomic.LoadInt64(&d.panics),
+		Blocked:     atomic.LoadInt64(&d.blocked),
+		BlockedTime: time.Duration(atomic.LoadInt64(&d.blockedTime)),
+	}
+}
+
+// Close stops the workers after they ran the queued handler calls. The
+// dispatcher must not be used anymore afterwards.
+func (d *EventDispatcher) Close() {
+	d.once.Do(func() {
+		for _, q := range d.queues {
+			close(q)
+		}
+	})
+	d.wg.Wait()
+}
+
+func (d *EventDispatcher) work(queue <-chan dispatchTask) {
+	defer d.wg.Done()
+
+	for task := range queue {
+		atomic.AddInt64(&d.queued, -1)
+		d.run(task)
+		atomic.AddInt64(&d.handled, 1)
+	}
+}
+
+// run calls a handler and recovers from its panics.
+func (d *EventDispatcher) run(task dispatchTask) {
+	defer func() {
+		if r := recover(); r != nil {
+			atomic.AddInt64(&d.panics, 1)
+			stack := debug.Stack()
+			if d.PanicHandler != nil {
+				d.PanicHandler(task.session, task.event, r, stack)
+				return
+			}
+			task.session.log(LogError, "event handler panicked: %v\n%s", r, stack)
+		}
+	}()
+
+	task.handler.Handle(task.session, task.event)
+}
+
+// queueIndex returns the queue of an event: the queue for its guild or
+// channel with ordering, the next queue for events without either.
+func (d *EventDispatcher) queueIndex(event interface{}) int {
+	if len(d.queues) == 1 {
+		return 0
+	}
+
+	guildID, channelID := eventKeys(event)
+	key := guildID
+	if d.ordering == EventOrderingChannel && channelID != "" {
+		key = channelID
+	}
+	if key == "" {
+		return int(atomic.AddUint32(&d.next, 1) % uint32(len(d.queues)))
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(d.queues)))
+}
+
+// eventKeys returns the guild and channel of an event. The guild and
+// channel events carry them as the ID of the embedded Guild or Channel,
+// all other events as GuildID and ChannelID fields.
+func eventKeys(event interface{}) (guildID, channelID string) {
+	if e, ok := event.(*Event); ok {
+		event = e.Struct
+	}
+
+	var g *Guild
+	var c *Channel
+	switch t := event.(type) {
+	case *GuildCreate:
+		g = t.Guild
+	case *GuildUpdate:
+		g = t.Guild
+	case *GuildDelete:
+		g = t.Guild
+	case *GuildAvailable:
+		g = t.Guild
+	case *GuildJoin:
+		g = t.Guild
+	case *ChannelCreate:
+		c = t.Channel
+	case *ChannelUpdate:
+		c = t.Channel
+	case *ChannelDelete:
+		c = t.Channel
+	default:
+		return eventField(event, "GuildID"), eventField(event, "ChannelID")
+	}
+
+	if g != nil {
+		return g.ID, ""
+	}
+	if c != nil {
+		return c.GuildID, c.ID
+	}
+	return "", ""
+}
+
+// eventField returns the string field name of an event struct, or of a
+// struct it embeds, or "" if it has none.
+func eventField(event interface{}, name string) string {
+	if e, ok := event.(*Event); ok {
+		event = e.Struct
+	}
+
+	v := reflect.ValueOf(event)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	f, ok := v.Type().FieldByName(name)
+	if !ok || f.Type.Kind() != reflect.String {
+		return ""
+	}
+
+	// Walk the embedded structs by hand, as FieldByIndex panics on a nil
+	// embedded pointer.
+	for _, i := range f.Index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v.String()
+}
@@ -0,0 +1,143 @@
+package discordgo
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEventDispatcherOrdering(t *testing.T) {
+	d := NewEventDispatcher(4, 1, EventOrderingChannel)
+	s := &Session{Dispatcher: d}
+
+	var mu sync.Mutex
+	got := make(map[string][]string)
+	s.AddHandler(func(_ *Session, m *MessageCreate) {
+		mu.Lock()
+		got[m.ChannelID] = append(got[m.ChannelID], m.ID)
+		mu.Unlock()
+	})
+
+	want := make(map[string][]string)
+	for i := 0; i < 50; i++ {
+		for _, channel := range []string{"a", "b", "c"} {
+			id := channel + string(rune('0'+i%10))
+			want[channel] = append(want[channel], id)
+			s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: id, ChannelID: channel}})
+		}
+	}
+	d.Close()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("handlers ran out of order per channel: got %v", got)
+	}
+	if stats := d.Stats(); stats.Queued != 0 || stats.Handled != 150 {
+		t.Errorf("got stats %+v, want 150 handled and none queued", stats)
+	}
+}
+
+func TestEventDispatcherPanic(t *testing.T) {
+	d := NewEventDispatcher(1, 0, EventOrderingNone)
+	var recovered interface{}
+	var event interface{}
+	d.PanicHandler = func(_ *Session, e interface{}, r interface{}, stack []byte) {
+		event, recovered = e, r
+	}
+	s := &Session{Dispatcher: d}
+
+	calls := 0
+	s.AddHandler(func(_ *Session, m *MessageCreate) {
+		calls++
+		if m.ID == "panic" {
+			panic("handler failed")
+		}
+	})
+	s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: "panic"}})
+	s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: "ok"}})
+	d.Close()
+
+	if recovered != "handler failed" || event.(*MessageCreate).ID != "panic" {
+		t.Errorf("PanicHandler got %v for %v", recovered, event)
+	}
+	if calls != 2 || d.Stats().Panics != 1 {
+		t.Errorf("got %d calls and %d panics, want the worker to survive the panic", calls, d.Stats().Panics)
+	}
+}
+
+func TestEventField(t *testing.T) {
+	if got := eventField(&MessageCreate{&Message{GuildID: "guild"}}, "GuildID"); got != "guild" {
+		t.Errorf("got %q, want guild", got)
+	}
+	if got := eventField(&MessageCreate{}, "GuildID"); got != "" {
+		t.Errorf("nil embedded struct: got %q", got)
+	}
+	if got := eventField(&Connect{}, "GuildID"); got != "" {
+		t.Errorf("event without field: got %q", got)
+	}
+}
+
+func TestEventKeys(t *testing.T) {
+	tests := []struct {
+		event          interface{}
+		guild, channel string
+	}{
+		{&GuildCreate{&Guild{ID: "g"}}, "g", ""},
+		{&GuildUpdate{&Guild{ID: "g"}}, "g", ""},
+		{&GuildDelete{Guild: &Guild{ID: "g"}}, "g", ""},
+		{&GuildAvailable{&Guild{ID: "g"}}, "g", ""},
+		{&GuildJoin{&Guild{ID: "g"}}, "g", ""},
+		{&ChannelCreate{&Channel{ID: "c", GuildID: "g"}}, "g", "c"},
+		{&ChannelUpdate{&Channel{ID: "c", GuildID: "g"}}, "g", "c"},
+		{&ChannelDelete{&Channel{ID: "c", GuildID: "g"}}, "g", "c"},
+		{&MessageCreate{&Message{ID: "m", ChannelID: "c", GuildID: "g"}}, "g", "c"},
+		{&Event{Struct: &GuildCreate{&Guild{ID: "g"}}}, "g", ""},
+		{&GuildCreate{}, "", ""},
+		{&Connect{}, "", ""},
+	}
+	for _, tt := range tests {
+		guild, channel := eventKeys(tt.event)
+		if guild != tt.guild || channel != tt.channel {
+			t.Errorf("eventKeys(%T) = %q, %q, want %q, %q", tt.event, guild, channel, tt.guild, tt.channel)
+		}
+	}
+}
+
+func TestEventDispatcherGuildOrdering(t *testing.T) {
+	d := NewEventDispatcher(4, 1, EventOrderingGuild)
+	s := &Session{Dispatcher: d}
+
+	var mu sync.Mutex
+	got := make(map[string][]string)
+	record := func(guildID, event string) {
+		mu.Lock()
+		got[guildID] = append(got[guildID], event)
+		mu.Unlock()
+	}
+	s.AddHandler(func(_ *Session, g *GuildCreate) { record(g.ID, "create") })
+	s.AddHandler(func(_ *Session, c *ChannelCreate) { record(c.GuildID, "channel "+c.ID) })
+	s.AddHandler(func(_ *Session, m *MessageCreate) { record(m.GuildID, "message "+m.ID) })
+	s.AddHandler(func(_ *Session, g *GuildDelete) { record(g.ID, "delete") })
+
+	want := make(map[string][]string)
+	for _, guild := range []string{"a", "b", "c", "d", "e"} {
+		s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: guild}})
+		s.handleEvent(channelCreateEventType, &ChannelCreate{&Channel{ID: guild + "1", GuildID: guild}})
+		want[guild] = []string{"create", "channel " + guild + "1"}
+	}
+	for i := 0; i < 10; i++ {
+		for _, guild := range []string{"a", "b", "c", "d", "e"} {
+			id := string(rune('0' + i))
+			s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: id, ChannelID: guild + "1", GuildID: guild}})
+			want[guild] = append(want[guild], "message "+id)
+		}
+	}
+	for _, guild := range []string{"a", "b", "c", "d", "e"} {
+		s.handleEvent(guildDeleteEventType, &GuildDelete{Guild: &Guild{ID: guild}})
+		want[guild] = append(want[guild], "delete")
+	}
+	d.Close()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("handlers ran out of order per guild:\ngot  %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains helpers to build message embeds.
+
+package discordgo
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// NewMessageEmbed returns a rich MessageEmbed, to be filled
+// using its chainable setters.
+func NewMessageEmbed() *MessageEmbed {
+	return &MessageEmbed{Type: EmbedTypeRich}
+}
+
+// SetTitle sets the title of the embed.
+func (e *MessageEmbed) SetTitle(title string) *MessageEmbed {
+	e.Title = title
+	return e
+}
+
+// SetDescription sets the description of the embed.
+func (e *MessageEmbed) SetDescription(description string) *MessageEmbed {
+	e.Description = description
+	return e
+}
+
+// SetURL sets the URL the title of the embed links to.
+func (e *MessageEmbed) SetURL(url string) *MessageEmbed {
+	e.URL = url
+	return e
+}
+
+// SetColor sets the color of the embed, e.g. 0xFF0000 for red.
+func (e *MessageEmbed) SetColor(color int) *MessageEmbed {
+	e.Color = color
+	return e
+}
+
+// SetTimestamp sets the timestamp shown in the footer of the embed.
+func (e *MessageEmbed) SetTimestamp(t time.Time) *MessageEmbed {
+	e.Timestamp = t.Format(time.RFC3339)
+	return e
+}
+
+// SetAuthor sets the author of the embed.
+// iconURL may be empty.
+func (e *MessageEmbed) SetAuthor(name, url, iconURL string) *MessageEmbed {
+	e.Author = &MessageEmbedAuthor{
+		Name:    name,
+		URL:     url,
+		IconURL: iconURL,
+	}
+	return e
+}
+
+// SetFooter sets the footer of the embed.
+// iconURL may be empty.
+func (e *MessageEmbed) SetFooter(text, iconURL string) *MessageEmbed {
+	e.Footer = &MessageEmbedFooter{
+		Text:    text,
+		IconURL: iconURL,
+	}
+	return e
+}
+
+// SetImage sets the image of the embed. Use File.AttachmentURL to show
+// an image uploaded with the same message.
+func (e *MessageEmbed) SetImage(url string) *MessageEmbed {
+	e.Image = &MessageEmbedImage{URL: url}
+	return e
+}
+
+// SetThumbnail sets the thumbnail of the embed.
+func (e *MessageEmbed) SetThumbnail(url string) *MessageEmbed {
+	e.Thumbnail = &MessageEmbedThumbnail{URL: url}
+	return e
+}
+
+// AddField adds a field to the embed.
+func (e *MessageEmbed) AddField(name, value string, inline bool) *MessageEmbed {
+	e.Fields = append(e.Fields, &MessageEmbedField{
+		Name:   name,
+		Value:  value,
+		Inline: inline,
+	})
+	return e
+}
+
+// Length returns the number of characters of the embed which count towards
+// EmbedLimitTotal, 0 for a nil embed.
+func (e *MessageEmbed) Length() int {
+	if e == nil {
+		return 0
+	}
+
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+	for _, f := range e.Fields {
+		n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+	}
+	return n
+}
+
+// Truncate shortens every text of the embed to its limit and drops fields
+// above EmbedLimitFields. If the embed is still longer than EmbedLimitTotal,
+// fields are removed from the end and finally the description is shortened.
+// Shortened texts end with an ellipsis.
+func (e *MessageEmbed) Truncate() *MessageEmbed {
+	if e == nil {
+		return nil
+	}
+
+	e.Title = truncate(e.Title, EmbedLimitTitle)
+	e.Description = truncate(e.Description, EmbedLimitDescription)
+	if e.Author != nil {
+		e.Author.Name = truncate(e.Author.Name, EmbedLimitAuthorName)
+	}
+	if e.Footer != nil {
+		e.Footer.Text = truncate(e.Footer.Text, EmbedLimitFooterText)
+	}
+
+	if len(e.Fields) > EmbedLimitFields {
+		e.Fields = e.Fields[:EmbedLimitFields]
+	}
+	for _, f := range e.Fields {
+		f.Name = truncate(f.Name, EmbedLimitFieldName)
+		f.Value = truncate(f.Value, EmbedLimitFieldValue)
+	}
+
+	for e.Length() > EmbedLimitTotal && len(e.Fields) > 0 {
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+	if over := e.Length() - EmbedLimitTotal; over > 0 {
+		e.Description = truncate(e.Description, utf8.RuneCountInString(e.Description)-over)
+	}
+
+	return e
+}
+
+// truncate shortens s to at most limit characters, replacing the end with
+// an ellipsis if anything was cut off.
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	if limit <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
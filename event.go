@@ -162,34 +162,47 @@ func (s *Session) removeEventHandlerInstance(t string, ehi *eventHandlerInstance
 	}
 }
 
-// Handles calling permanent and once handlers for an event type.
-func (s *Session) handle(t string, i interface{}) {
+// eventHandlers returns the permanent and once handlers for an event type,
+// and removes the once handlers.
+func (s *Session) eventHandlers(t string) []EventHandler {
+	s.handlersMu.RLock()
+	handlers := make([]EventHandler, 0, len(s.handlers[t]))
 	for _, eh := range s.handlers[t] {
-		if s.SyncEvents {
-			eh.eventHandler.Handle(s, i)
-		} else {
-			go eh.eventHandler.Handle(s, i)
-		}
+		handlers = append(handlers, eh.eventHandler)
 	}
+	once := len(s.onceHandlers[t]) > 0
+	s.handlersMu.RUnlock()
 
-	if len(s.onceHandlers[t]) > 0 {
+	if once {
+		s.handlersMu.Lock()
 		for _, eh := range s.onceHandlers[t] {
-			if s.SyncEvents {
-				eh.eventHandler.Handle(s, i)
-			} else {
-				go eh.eventHandler.Handle(s, i)
-			}
+			handlers = append(handlers, eh.eventHandler)
 		}
 		s.onceHandlers[t] = nil
+		s.handlersMu.Unlock()
+	}
+	return handlers
+}
+
+// Handles calling permanent and once handlers for an event type.
+// The handlers are called without holding the handlers lock, so they and
+// a blocking Dispatcher can add and remove handlers.
+func (s *Session) handle(t string, i interface{}) {
+	for _, eh := range s.eventHandlers(t) {
+		switch {
+		case s.Dispatcher != nil:
+			s.Dispatcher.Dispatch(s, eh, i)
+		case s.SyncEvents:
+			eh.Handle(s, i)
+		default:
+			go eh.Handle(s, i)
+		}
 	}
 }
 
 // Handles an event type by calling internal methods, firing handlers and firing the
 // interface{} event.
 func (s *Session) handleEvent(t string, i interface{}) {
-	s.handlersMu.RLock()
-	defer s.handlersMu.RUnlock()
-
 	// All events are dispatched internally first.
 	s.onInterface(i)
 
@@ -198,6 +211,9 @@ func (s *Session) handleEvent(t string, i interface{}) {
 
 	// Finally they are dispatched to any typed handlers.
 	s.handle(t, i)
+
+	// Synthetic events which follow from the event are dispatched after it.
+	s.onGuildReadiness(i)
 }
 
 // setGuildIds will set the GuildID on all the members of a guild.
@@ -232,6 +248,8 @@ func (s *Session) onInterface(i interface{}) {
 		go s.onVoiceServerUpdate(t)
 	case *VoiceStateUpdate:
 		go s.onVoiceStateUpdate(t)
+	case *GuildMembersChunk:
+		s.onGuildMembersChunk(t)
 	}
 	err := s.State.OnInterface(s, i)
 	if err != nil {
@@ -242,6 +260,10 @@ func (s *Session) onInterface(i interface{}) {
 // onReady handles the ready event.
 func (s *Session) onReady(r *Ready) {
 
-	// Store the SessionID within the Session struct.
+	// Store the SessionID and the Gateway to resume it on within the
+	// Session struct.
+	s.gatewaySessionMu.Lock()
 	s.sessionID = r.SessionID
+	s.resumeGatewayURL = r.ResumeGatewayURL
+	s.gatewaySessionMu.Unlock()
 }
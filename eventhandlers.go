@@ -7,56 +7,81 @@ package discordgo
 // Event type values are used to match the events returned by Discord.
 // EventTypes surrounded by __ are synthetic and are internal to DiscordGo.
 const (
-	channelCreateEventType             = "CHANNEL_CREATE"
-	channelDeleteEventType             = "CHANNEL_DELETE"
-	channelPinsUpdateEventType         = "CHANNEL_PINS_UPDATE"
-	channelUpdateEventType             = "CHANNEL_UPDATE"
-	connectEventType                   = "__CONNECT__"
-	disconnectEventType                = "__DISCONNECT__"
-	eventEventType                     = "__EVENT__"
-	guildBanAddEventType               = "GUILD_BAN_ADD"
-	guildBanRemoveEventType            = "GUILD_BAN_REMOVE"
-	guildCreateEventType               = "GUILD_CREATE"
-	guildDeleteEventType               = "GUILD_DELETE"
-	guildEmojisUpdateEventType         = "GUILD_EMOJIS_UPDATE"
-	guildIntegrationsUpdateEventType   = "GUILD_INTEGRATIONS_UPDATE"
-	guildMemberAddEventType            = "GUILD_MEMBER_ADD"
-	guildMemberRemoveEventType         = "GUILD_MEMBER_REMOVE"
-	guildMemberUpdateEventType         = "GUILD_MEMBER_UPDATE"
-	guildMembersChunkEventType         = "GUILD_MEMBERS_CHUNK"
-	guildRoleCreateEventType           = "GUILD_ROLE_CREATE"
-	guildRoleDeleteEventType           = "GUILD_ROLE_DELETE"
-	guildRoleUpdateEventType           = "GUILD_ROLE_UPDATE"
-	guildUpdateEventType               = "GUILD_UPDATE"
-	interactionCreateEventType         = "INTERACTION_CREATE"
-	guildScheduledEventCreateEventType = "GUILD_SCHEDULED_EVENT_CREATE"
-	guildScheduledEventUpdateEventType = "GUILD_SCHEDULED_EVENT_UPDATE"
-	guildScheduledEventDeleteEventType = "GUILD_SCHEDULED_EVENT_DELETE"
-	messageAckEventType                = "MESSAGE_ACK"
-	messageCreateEventType             = "MESSAGE_CREATE"
-	messageDeleteEventType             = "MESSAGE_DELETE"
-	messageDeleteBulkEventType         = "MESSAGE_DELETE_BULK"
-	messageReactionAddEventType        = "MESSAGE_REACTION_ADD"
-	messageReactionRemoveEventType     = "MESSAGE_REACTION_REMOVE"
-	messageReactionRemoveAllEventType  = "MESSAGE_REACTION_REMOVE_ALL"
-	messageUpdateEventType             = "MESSAGE_UPDATE"
-	presenceUpdateEventType            = "PRESENCE_UPDATE"
-	presencesReplaceEventType          = "PRESENCES_REPLACE"
-	rateLimitEventType                 = "__RATE_LIMIT__"
-	readyEventType                     = "READY"
-	relationshipAddEventType           = "RELATIONSHIP_ADD"
-	relationshipRemoveEventType        = "RELATIONSHIP_REMOVE"
-	resumedEventType                   = "RESUMED"
-	typingStartEventType               = "TYPING_START"
-	userGuildSettingsUpdateEventType   = "USER_GUILD_SETTINGS_UPDATE"
-	userNoteUpdateEventType            = "USER_NOTE_UPDATE"
-	userSettingsUpdateEventType        = "USER_SETTINGS_UPDATE"
-	userUpdateEventType                = "USER_UPDATE"
-	voiceServerUpdateEventType         = "VOICE_SERVER_UPDATE"
-	voiceStateUpdateEventType          = "VOICE_STATE_UPDATE"
-	webhooksUpdateEventType            = "WEBHOOKS_UPDATE"
+	applicationCommandPermissionsUpdateEventType = "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+	channelCreateEventType                       = "CHANNEL_CREATE"
+	channelDeleteEventType                       = "CHANNEL_DELETE"
+	channelPinsUpdateEventType                   = "CHANNEL_PINS_UPDATE"
+	channelUpdateEventType                       = "CHANNEL_UPDATE"
+	connectEventType                             = "__CONNECT__"
+	disconnectEventType                          = "__DISCONNECT__"
+	eventEventType                               = "__EVENT__"
+	gatewayFatalErrorEventType                   = "__GATEWAY_FATAL_ERROR__"
+	guildAvailableEventType                      = "__GUILD_AVAILABLE__"
+	guildBanAddEventType                         = "GUILD_BAN_ADD"
+	guildBanRemoveEventType                      = "GUILD_BAN_REMOVE"
+	guildCreateEventType                         = "GUILD_CREATE"
+	guildDeleteEventType                         = "GUILD_DELETE"
+	guildEmojisUpdateEventType                   = "GUILD_EMOJIS_UPDATE"
+	guildIntegrationsUpdateEventType             = "GUILD_INTEGRATIONS_UPDATE"
+	guildJoinEventType                           = "__GUILD_JOIN__"
+	guildMemberAddEventType                      = "GUILD_MEMBER_ADD"
+	guildMemberRemoveEventType                   = "GUILD_MEMBER_REMOVE"
+	guildMemberUpdateEventType                   = "GUILD_MEMBER_UPDATE"
+	guildMembersChunkEventType                   = "GUILD_MEMBERS_CHUNK"
+	guildRoleCreateEventType                     = "GUILD_ROLE_CREATE"
+	guildRoleDeleteEventType                     = "GUILD_ROLE_DELETE"
+	guildRoleUpdateEventType                     = "GUILD_ROLE_UPDATE"
+	guildScheduledEventCreateEventType           = "GUILD_SCHEDULED_EVENT_CREATE"
+	guildScheduledEventDeleteEventType           = "GUILD_SCHEDULED_EVENT_DELETE"
+	guildScheduledEventUpdateEventType           = "GUILD_SCHEDULED_EVENT_UPDATE"
+	guildUpdateEventType                         = "GUILD_UPDATE"
+	guildsReadyEventType                         = "__GUILDS_READY__"
+	interactionCreateEventType                   = "INTERACTION_CREATE"
+	messageAckEventType                          = "MESSAGE_ACK"
+	messageCreateEventType                       = "MESSAGE_CREATE"
+	messageDeleteEventType                       = "MESSAGE_DELETE"
+	messageDeleteBulkEventType                   = "MESSAGE_DELETE_BULK"
+	messageReactionAddEventType                  = "MESSAGE_REACTION_ADD"
+	messageReactionRemoveEventType               = "MESSAGE_REACTION_REMOVE"
+	messageReactionRemoveAllEventType            = "MESSAGE_REACTION_REMOVE_ALL"
+	messageUpdateEventType                       = "MESSAGE_UPDATE"
+	presenceUpdateEventType                      = "PRESENCE_UPDATE"
+	presencesReplaceEventType                    = "PRESENCES_REPLACE"
+	rateLimitEventType                           = "__RATE_LIMIT__"
+	readyEventType                               = "READY"
+	relationshipAddEventType                     = "RELATIONSHIP_ADD"
+	relationshipRemoveEventType                  = "RELATIONSHIP_REMOVE"
+	resumedEventType                             = "RESUMED"
+	typingStartEventType                         = "TYPING_START"
+	userGuildSettingsUpdateEventType             = "USER_GUILD_SETTINGS_UPDATE"
+	userNoteUpdateEventType                      = "USER_NOTE_UPDATE"
+	userSettingsUpdateEventType                  = "USER_SETTINGS_UPDATE"
+	userUpdateEventType                          = "USER_UPDATE"
+	voiceServerUpdateEventType                   = "VOICE_SERVER_UPDATE"
+	voiceStateUpdateEventType                    = "VOICE_STATE_UPDATE"
+	webhooksUpdateEventType                      = "WEBHOOKS_UPDATE"
 )
 
+// applicationCommandPermissionsUpdateEventHandler is an event handler for ApplicationCommandPermissionsUpdate events.
+type applicationCommandPermissionsUpdateEventHandler func(*Session, *ApplicationCommandPermissionsUpdate)
+
+// Type returns the event type for ApplicationCommandPermissionsUpdate events.
+func (eh applicationCommandPermissionsUpdateEventHandler) Type() string {
+	return applicationCommandPermissionsUpdateEventType
+}
+
+// New returns a new instance of ApplicationCommandPermissionsUpdate.
+func (eh applicationCommandPermissionsUpdateEventHandler) New() interface{} {
+	return &ApplicationCommandPermissionsUpdate{}
+}
+
+// Handle is the handler for ApplicationCommandPermissionsUpdate events.
+func (eh applicationCommandPermissionsUpdateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*ApplicationCommandPermissionsUpdate); ok {
+		eh(s, t)
+	}
+}
+
 // channelCreateEventHandler is an event handler for ChannelCreate events.
 type channelCreateEventHandler func(*Session, *ChannelCreate)
 
@@ -182,6 +207,36 @@ func (eh eventEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// gatewayFatalErrorEventHandler is an event handler for GatewayFatalError events.
+type gatewayFatalErrorEventHandler func(*Session, *GatewayFatalError)
+
+// Type returns the event type for GatewayFatalError events.
+func (eh gatewayFatalErrorEventHandler) Type() string {
+	return gatewayFatalErrorEventType
+}
+
+// Handle is the handler for GatewayFatalError events.
+func (eh gatewayFatalErrorEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GatewayFatalError); ok {
+		eh(s, t)
+	}
+}
+
+// guildAvailableEventHandler is an event handler for GuildAvailable events.
+type guildAvailableEventHandler func(*Session, *GuildAvailable)
+
+// Type returns the event type for GuildAvailable events.
+func (eh guildAvailableEventHandler) Type() string {
+	return guildAvailableEventType
+}
+
+// Handle is the handler for GuildAvailable events.
+func (eh guildAvailableEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildAvailable); ok {
+		eh(s, t)
+	}
+}
+
 // guildBanAddEventHandler is an event handler for GuildBanAdd events.
 type guildBanAddEventHandler func(*Session, *GuildBanAdd)
 
@@ -302,62 +357,17 @@ func (eh guildIntegrationsUpdateEventHandler) Handle(s *Session, i interface{})
 	}
 }
 
-// guildScheduledEventCreateEventHandler is an event handler for GuildScheduledEventCreate events.
-type guildScheduledEventCreateEventHandler func(*Session, *GuildScheduledEventCreate)
-
-// Type returns the event type for GuildScheduledEventCreate events.
-func (eh guildScheduledEventCreateEventHandler) Type() string {
-	return guildScheduledEventCreateEventType
-}
+// guildJoinEventHandler is an event handler for GuildJoin events.
+type guildJoinEventHandler func(*Session, *GuildJoin)
 
-// New returns a new instance of GuildScheduledEventCreate.
-func (eh guildScheduledEventCreateEventHandler) New() interface{} {
-	return &GuildScheduledEventCreate{}
+// Type returns the event type for GuildJoin events.
+func (eh guildJoinEventHandler) Type() string {
+	return guildJoinEventType
 }
 
-// Handle is the handler for GuildScheduledEventCreate events.
-func (eh guildScheduledEventCreateEventHandler) Handle(s *Session, i interface{}) {
-	if t, ok := i.(*GuildScheduledEventCreate); ok {
-		eh(s, t)
-	}
-}
-
-// guildScheduledEventUpdateEventHandler is an event handler for GuildScheduledEventUpdate events.
-type guildScheduledEventUpdateEventHandler func(*Session, *GuildScheduledEventUpdate)
-
-// Type returns the event type for GuildScheduledEventUpdate events.
-func (eh guildScheduledEventUpdateEventHandler) Type() string {
-	return guildScheduledEventUpdateEventType
-}
-
-// New returns a new instance of GuildScheduledEventUpdate.
-func (eh guildScheduledEventUpdateEventHandler) New() interface{} {
-	return &GuildScheduledEventUpdate{}
-}
-
-// Handle is the handler for GuildScheduledEventUpdate events.
-func (eh guildScheduledEventUpdateEventHandler) Handle(s *Session, i interface{}) {
-	if t, ok := i.(*GuildScheduledEventUpdate); ok {
-		eh(s, t)
-	}
-}
-
-// guildScheduledEventDeleteEventHandler is an event handler for GuildScheduledEventDelete events.
-type guildScheduledEventDeleteEventHandler func(*Session, *GuildScheduledEventDelete)
-
-// Type returns the event type for GuildScheduledEventDelete events.
-func (eh guildScheduledEventDeleteEventHandler) Type() string {
-	return guildScheduledEventDeleteEventType
-}
-
-// New returns a new instance of GuildScheduledEventDelete.
-func (eh guildScheduledEventDeleteEventHandler) New() interface{} {
-	return &GuildScheduledEventDelete{}
-}
-
-// Handle is the handler for GuildScheduledEventDelete events.
-func (eh guildScheduledEventDeleteEventHandler) Handle(s *Session, i interface{}) {
-	if t, ok := i.(*GuildScheduledEventDelete); ok {
+// Handle is the handler for GuildJoin events.
+func (eh guildJoinEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildJoin); ok {
 		eh(s, t)
 	}
 }
@@ -502,6 +512,66 @@ func (eh guildRoleUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildScheduledEventCreateEventHandler is an event handler for GuildScheduledEventCreate events.
+type guildScheduledEventCreateEventHandler func(*Session, *GuildScheduledEventCreate)
+
+// Type returns the event type for GuildScheduledEventCreate events.
+func (eh guildScheduledEventCreateEventHandler) Type() string {
+	return guildScheduledEventCreateEventType
+}
+
+// New returns a new instance of GuildScheduledEventCreate.
+func (eh guildScheduledEventCreateEventHandler) New() interface{} {
+	return &GuildScheduledEventCreate{}
+}
+
+// Handle is the handler for GuildScheduledEventCreate events.
+func (eh guildScheduledEventCreateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildScheduledEventCreate); ok {
+		eh(s, t)
+	}
+}
+
+// guildScheduledEventDeleteEventHandler is an event handler for GuildScheduledEventDelete events.
+type guildScheduledEventDeleteEventHandler func(*Session, *GuildScheduledEventDelete)
+
+// Type returns the event type for GuildScheduledEventDelete events.
+func (eh guildScheduledEventDeleteEventHandler) Type() string {
+	return guildScheduledEventDeleteEventType
+}
+
+// New returns a new instance of GuildScheduledEventDelete.
+func (eh guildScheduledEventDeleteEventHandler) New() interface{} {
+	return &GuildScheduledEventDelete{}
+}
+
+// Handle is the handler for GuildScheduledEventDelete events.
+func (eh guildScheduledEventDeleteEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildScheduledEventDelete); ok {
+		eh(s, t)
+	}
+}
+
+// guildScheduledEventUpdateEventHandler is an event handler for GuildScheduledEventUpdate events.
+type guildScheduledEventUpdateEventHandler func(*Session, *GuildScheduledEventUpdate)
+
+// Type returns the event type for GuildScheduledEventUpdate events.
+func (eh guildScheduledEventUpdateEventHandler) Type() string {
+	return guildScheduledEventUpdateEventType
+}
+
+// New returns a new instance of GuildScheduledEventUpdate.
+func (eh guildScheduledEventUpdateEventHandler) New() interface{} {
+	return &GuildScheduledEventUpdate{}
+}
+
+// Handle is the handler for GuildScheduledEventUpdate events.
+func (eh guildScheduledEventUpdateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildScheduledEventUpdate); ok {
+		eh(s, t)
+	}
+}
+
 // guildUpdateEventHandler is an event handler for GuildUpdate events.
 type guildUpdateEventHandler func(*Session, *GuildUpdate)
 
@@ -522,6 +592,21 @@ func (eh guildUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildsReadyEventHandler is an event handler for GuildsReady events.
+type guildsReadyEventHandler func(*Session, *GuildsReady)
+
+// Type returns the event type for GuildsReady events.
+func (eh guildsReadyEventHandler) Type() string {
+	return guildsReadyEventType
+}
+
+// Handle is the handler for GuildsReady events.
+func (eh guildsReadyEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildsReady); ok {
+		eh(s, t)
+	}
+}
+
 // interactionCreateEventHandler is an event handler for InteractionCreate events.
 type interactionCreateEventHandler func(*Session, *InteractionCreate)
 
@@ -1001,6 +1086,8 @@ func handlerForInterface(handler interface{}) EventHandler {
 	switch v := handler.(type) {
 	case func(*Session, interface{}):
 		return interfaceEventHandler(v)
+	case func(*Session, *ApplicationCommandPermissionsUpdate):
+		return applicationCommandPermissionsUpdateEventHandler(v)
 	case func(*Session, *ChannelCreate):
 		return channelCreateEventHandler(v)
 	case func(*Session, *ChannelDelete):
@@ -1015,6 +1102,10 @@ func handlerForInterface(handler interface{}) EventHandler {
 		return disconnectEventHandler(v)
 	case func(*Session, *Event):
 		return eventEventHandler(v)
+	case func(*Session, *GatewayFatalError):
+		return gatewayFatalErrorEventHandler(v)
+	case func(*Session, *GuildAvailable):
+		return guildAvailableEventHandler(v)
 	case func(*Session, *GuildBanAdd):
 		return guildBanAddEventHandler(v)
 	case func(*Session, *GuildBanRemove):
@@ -1027,12 +1118,8 @@ func handlerForInterface(handler interface{}) EventHandler {
 		return guildEmojisUpdateEventHandler(v)
 	case func(*Session, *GuildIntegrationsUpdate):
 		return guildIntegrationsUpdateEventHandler(v)
-	case func(*Session, *GuildScheduledEventCreate):
-		return guildScheduledEventCreateEventHandler(v)
-	case func(*Session, *GuildScheduledEventUpdate):
-		return guildScheduledEventUpdateEventHandler(v)
-	case func(*Session, *GuildScheduledEventDelete):
-		return guildScheduledEventDeleteEventHandler(v)
+	case func(*Session, *GuildJoin):
+		return guildJoinEventHandler(v)
 	case func(*Session, *GuildMemberAdd):
 		return guildMemberAddEventHandler(v)
 	case func(*Session, *GuildMemberRemove):
@@ -1047,8 +1134,16 @@ func handlerForInterface(handler interface{}) EventHandler {
 		return guildRoleDeleteEventHandler(v)
 	case func(*Session, *GuildRoleUpdate):
 		return guildRoleUpdateEventHandler(v)
+	case func(*Session, *GuildScheduledEventCreate):
+		return guildScheduledEventCreateEventHandler(v)
+	case func(*Session, *GuildScheduledEventDelete):
+		return guildScheduledEventDeleteEventHandler(v)
+	case func(*Session, *GuildScheduledEventUpdate):
+		return guildScheduledEventUpdateEventHandler(v)
 	case func(*Session, *GuildUpdate):
 		return guildUpdateEventHandler(v)
+	case func(*Session, *GuildsReady):
+		return guildsReadyEventHandler(v)
 	case func(*Session, *InteractionCreate):
 		return interactionCreateEventHandler(v)
 	case func(*Session, *MessageAck):
@@ -1103,6 +1198,7 @@ func handlerForInterface(handler interface{}) EventHandler {
 }
 
 func init() {
+	registerInterfaceProvider(applicationCommandPermissionsUpdateEventHandler(nil))
 	registerInterfaceProvider(channelCreateEventHandler(nil))
 	registerInterfaceProvider(channelDeleteEventHandler(nil))
 	registerInterfaceProvider(channelPinsUpdateEventHandler(nil))
@@ -1113,9 +1209,6 @@ func init() {
 	registerInterfaceProvider(guildDeleteEventHandler(nil))
 	registerInterfaceProvider(guildEmojisUpdateEventHandler(nil))
 	registerInterfaceProvider(guildIntegrationsUpdateEventHandler(nil))
-	registerInterfaceProvider(guildScheduledEventCreateEventHandler(nil))
-	registerInterfaceProvider(guildScheduledEventUpdateEventHandler(nil))
-	registerInterfaceProvider(guildScheduledEventDeleteEventHandler(nil))
 	registerInterfaceProvider(guildMemberAddEventHandler(nil))
 	registerInterfaceProvider(guildMemberRemoveEventHandler(nil))
 	registerInterfaceProvider(guildMemberUpdateEventHandler(nil))
@@ -1123,6 +1216,9 @@ func init() {
 	registerInterfaceProvider(guildRoleCreateEventHandler(nil))
 	registerInterfaceProvider(guildRoleDeleteEventHandler(nil))
 	registerInterfaceProvider(guildRoleUpdateEventHandler(nil))
+	registerInterfaceProvider(guildScheduledEventCreateEventHandler(nil))
+	registerInterfaceProvider(guildScheduledEventDeleteEventHandler(nil))
+	registerInterfaceProvider(guildScheduledEventUpdateEventHandler(nil))
 	registerInterfaceProvider(guildUpdateEventHandler(nil))
 	registerInterfaceProvider(interactionCreateEventHandler(nil))
 	registerInterfaceProvider(messageAckEventHandler(nil))
@@ -17,6 +17,39 @@ type Connect struct{}
 // This is a synthetic event and is not dispatched by Discord.
 type Disconnect struct{}
 
+// GatewayFatalError is the data for a GatewayFatalError event, emitted
+// when the gateway closed the connection with a code after which the
+// session does not reconnect, e.g. because the token is invalid.
+// This is a synthetic event and is not dispatched by Discord.
+type GatewayFatalError struct {
+	*GatewayCloseError
+}
+
+// GuildAvailable is the data for a GuildAvailable event, emitted after
+// the GuildCreate event of a guild which was unavailable because of an
+// outage.
+// This is a synthetic event and is not dispatched by Discord.
+type GuildAvailable struct {
+	*Guild
+}
+
+// GuildJoin is the data for a GuildJoin event, emitted after the
+// GuildCreate event of a guild the bot joined.
+// This is a synthetic event and is not dispatched by Discord.
+type GuildJoin struct {
+	*Guild
+}
+
+// GuildsReady is the data for a GuildsReady event, emitted when the
+// GuildCreate events of all guilds in the READY packet were received, or
+// when Session.GuildsReadyTimeout passed before.
+// This is a synthetic event and is not dispatched by Discord.
+type GuildsReady struct {
+	// The IDs of the guilds which were still unavailable at the timeout,
+	// or which Discord reported to be in an outage.
+	Unavailable []string
+}
+
 // RateLimit is the data for a RateLimit event.
 // This is a synthetic event and is not dispatched by Discord.
 type RateLimit struct {
@@ -36,12 +69,13 @@ type Event struct {
 
 // A Ready stores all data for the websocket READY event.
 type Ready struct {
-	Version         int          `json:"v"`
-	SessionID       string       `json:"session_id"`
-	User            *User        `json:"user"`
-	ReadState       []*ReadState `json:"read_state"`
-	PrivateChannels []*Channel   `json:"private_channels"`
-	Guilds          []*Guild     `json:"guilds"`
+	Version          int          `json:"v"`
+	SessionID        string       `json:"session_id"`
+	ResumeGatewayURL string       `json:"resume_gateway_url"`
+	User             *User        `json:"user"`
+	ReadState        []*ReadState `json:"read_state"`
+	PrivateChannels  []*Channel   `json:"private_channels"`
+	Guilds           []*Guild     `json:"guilds"`
 
 	// Undocumented fields
 	Settings          *Settings            `json:"user_settings"`
@@ -144,7 +178,9 @@ type GuildMembersChunk struct {
 	Members    []*Member   `json:"members"`
 	ChunkIndex int         `json:"chunk_index"`
 	ChunkCount int         `json:"chunk_count"`
+	NotFound   []string    `json:"not_found,omitempty"`
 	Presences  []*Presence `json:"presences,omitempty"`
+	Nonce      string      `json:"nonce,omitempty"`
 }
 
 // GuildIntegrationsUpdate is the data for a GuildIntegrationsUpdate event.
@@ -305,3 +341,9 @@ type InteractionCreate struct {
 func (i *InteractionCreate) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, &i.Interaction)
 }
+
+// ApplicationCommandPermissionsUpdate is the data for an
+// ApplicationCommandPermissionsUpdate event
+type ApplicationCommandPermissionsUpdate struct {
+	*GuildApplicationCommandPermissions
+}
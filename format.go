@@ -0,0 +1,351 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains functions to format, escape and parse message content.
+
+package discordgo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampStyle is the display style of a timestamp in message content.
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+type TimestampStyle string
+
+// Valid TimestampStyle values
+const (
+	TimestampStyleDefault       TimestampStyle = ""
+	TimestampStyleShortTime     TimestampStyle = "t"
+	TimestampStyleLongTime      TimestampStyle = "T"
+	TimestampStyleShortDate     TimestampStyle = "d"
+	TimestampStyleLongDate      TimestampStyle = "D"
+	TimestampStyleShortDateTime TimestampStyle = "f"
+	TimestampStyleLongDateTime  TimestampStyle = "F"
+	TimestampStyleRelativeTime  TimestampStyle = "R"
+)
+
+// markdownEscaper escapes every character with a meaning in Discord markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	"`", "\\`",
+	`|`, `\|`,
+	`>`, `\>`,
+	`#`, `\#`,
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// EscapeMarkdown escapes all markdown in s, so it is shown as is.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// EscapeMentions breaks all user, role, @everyone and @here mentions in s by
+// inserting a zero width space after the @, so they neither ping nor render.
+func EscapeMentions(s string) string {
+	return strings.Replace(s, "@", "@\u200b", -1)
+}
+
+// FormatTimestamp returns a timestamp which is shown to every user in
+// their own timezone, in the given style.
+func FormatTimestamp(t time.Time, style TimestampStyle) string {
+	if style == TimestampStyleDefault {
+		return "<t:" + strconv.FormatInt(t.Unix(), 10) + ">"
+	}
+	return "<t:" + strconv.FormatInt(t.Unix(), 10) + ":" + string(style) + ">"
+}
+
+// FormatUserMention returns the mention of the user with the given ID.
+func FormatUserMention(userID string) string {
+	return "<@" + userID + ">"
+}
+
+// FormatRoleMention returns the mention of the role with the given ID.
+func FormatRoleMention(roleID string) string {
+	return "<@&" + roleID + ">"
+}
+
+// FormatChannelMention returns the mention of the channel with the given ID.
+func FormatChannelMention(channelID string) string {
+	return "<#" + channelID + ">"
+}
+
+// FormatSlashCommandMention returns a clickable mention of a slash command.
+// name may include subcommands separated by spaces, e.g. "config set".
+func FormatSlashCommandMention(name, commandID string) string {
+	return "</" + name + ":" + commandID + ">"
+}
+
+// FormatBold returns s in bold.
+func FormatBold(s string) string {
+	return "**" + s + "**"
+}
+
+// FormatItalic returns s in italics.
+func FormatItalic(s string) string {
+	return "*" + s + "*"
+}
+
+// FormatUnderline returns s underlined.
+func FormatUnderline(s string) string {
+	return "__" + s + "__"
+}
+
+// FormatStrikethrough returns s struck through.
+func FormatStrikethrough(s string) string {
+	return "~~" + s + "~~"
+}
+
+// FormatSpoiler returns s hidden as a spoiler.
+func FormatSpoiler(s string) string {
+	return "||" + s + "||"
+}
+
+// FormatInlineCode returns s as inline code.
+func FormatInlineCode(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// FormatCodeBlock returns code in a code block, highlighted for the given
+// language which may be empty. Fences inside code are broken up with a zero
+// width space so they can't end the block early.
+func FormatCodeBlock(language, code string) string {
+	code = strings.Replace(code, "```", "`\u200b``", -1)
+	return "```" + language + "\n" + code + "\n```"
+}
+
+// ContentTokenType is the type of a ContentToken.
+type ContentTokenType int
+
+// Valid ContentTokenType values
+const (
+	ContentTokenText ContentTokenType = iota
+	ContentTokenUserMention
+	ContentTokenRoleMention
+	ContentTokenChannelMention
+	ContentTokenEveryoneMention
+	ContentTokenHereMention
+	ContentTokenSlashCommandMention
+	ContentTokenEmoji
+	ContentTokenTimestamp
+	ContentTokenURL
+	ContentTokenBold
+	ContentTokenItalic
+	ContentTokenUnderline
+	ContentTokenStrikethrough
+	ContentTokenSpoiler
+	ContentTokenInlineCode
+	ContentTokenCodeBlock
+)
+
+// A ContentToken is a part of message content as returned by ParseContent.
+type ContentToken struct {
+	Type ContentTokenType
+
+	// The source of the token in the content.
+	Raw string
+
+	// The text of text tokens and code, the URL of URL tokens.
+	Text string
+
+	// The ID of mentions and custom emojis.
+	ID string
+
+	// The name of custom emojis and slash commands.
+	Name string
+
+	// If true, the custom emoji is animated.
+	Animated bool
+
+	// The time and style of timestamps.
+	Time  time.Time
+	Style TimestampStyle
+
+	// The language of code blocks.
+	Language string
+
+	// The content of markdown spans.
+	Children []*ContentToken
+}
+
+var (
+	patternUserMention    = regexp.MustCompile(`^<@!?([0-9]+)>`)
+	patternRoleMention    = regexp.MustCompile(`^<@&([0-9]+)>`)
+	patternChannelMention = regexp.MustCompile(`^<#([0-9]+)>`)
+	patternCommandMention = regexp.MustCompile(`^</([^:<>]+):([0-9]+)>`)
+	patternCustomEmoji    = regexp.MustCompile(`^<(a?):([A-Za-z0-9_~]+):([0-9]+)>`)
+	patternTimestamp      = regexp.MustCompile(`^<t:(-?[0-9]+)(?::([tTdDfFR]))?>`)
+	patternURL            = regexp.MustCompile(`^https?://[^\s<>]*[^\s<>.,:;"')\]]`)
+	patternEnclosedURL    = regexp.MustCompile(`^<(https?://[^\s<>]+)>`)
+)
+
+// markdownSpans lists the delimiters of markdown spans, longest first so
+// e.g. ** is not taken for two italic delimiters.
+var markdownSpans = []struct {
+	delim string
+	typ   ContentTokenType
+}{
+	{"||", ContentTokenSpoiler},
+	{"**", ContentTokenBold},
+	{"__", ContentTokenUnderline},
+	{"~~", ContentTokenStrikethrough},
+	{"*", ContentTokenItalic},
+	{"_", ContentTokenItalic},
+}
+
+// ParseContent splits message content into tokens of text, mentions, custom
+// emojis, timestamps, URLs and markdown spans. Markdown spans hold the tokens
+// of their content in Children. It understands the commonly used subset of
+// Discord markdown, unclosed delimiters are kept as text.
+func ParseContent(content string) []*ContentToken {
+	var tokens []*ContentToken
+	var text strings.Builder
+
+	// start is the offset of the text which is not part of a token yet.
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			tokens = append(tokens, &ContentToken{Type: ContentTokenText, Raw: content[start:end], Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(content); {
+		token, n := parseToken(content[i:])
+		if token == nil {
+			// Escaped characters are always text.
+			if content[i] == '\\' && i+1 < len(content) && isMarkdownPunct(content[i+1]) {
+				text.WriteByte(content[i+1])
+				i += 2
+				continue
+			}
+			text.WriteByte(content[i])
+			i++
+			continue
+		}
+
+		flush(i)
+		tokens = append(tokens, token)
+		i += n
+		start = i
+	}
+	flush(len(content))
+
+	return tokens
+}
+
+// parseToken returns the token at the start of s and its length in bytes,
+// or nil if s starts with plain text.
+func parseToken(s string) (*ContentToken, int) {
+	switch {
+	case strings.HasPrefix(s, "```"):
+		end := strings.Index(s[3:], "```")
+		if end < 0 {
+			return nil, 0
+		}
+		raw := s[:end+6]
+		code := s[3 : end+3]
+		t := &ContentToken{Type: ContentTokenCodeBlock, Raw: raw}
+		if nl := strings.IndexByte(code, '\n'); nl >= 0 && !strings.ContainsAny(code[:nl], " \t") {
+			t.Language, code = code[:nl], code[nl+1:]
+		}
+		t.Text = strings.TrimSuffix(code, "\n")
+		return t, len(raw)
+
+	case s[0] == '`':
+		delim := "`"
+		if strings.HasPrefix(s, "``") {
+			delim = "``"
+		}
+		end := strings.Index(s[len(delim):], delim)
+		if end <= 0 {
+			return nil, 0
+		}
+		raw := s[:end+2*len(delim)]
+		code := s[len(delim) : end+len(delim)]
+		if delim == "``" {
+			code = strings.TrimSpace(code)
+		}
+		return &ContentToken{Type: ContentTokenInlineCode, Raw: raw, Text: code}, len(raw)
+
+	case s[0] == '<':
+		return parseAngleToken(s)
+
+	case strings.HasPrefix(s, "@everyone"):
+		return &ContentToken{Type: ContentTokenEveryoneMention, Raw: "@everyone"}, len("@everyone")
+
+	case strings.HasPrefix(s, "@here"):
+		return &ContentToken{Type: ContentTokenHereMention, Raw: "@here"}, len("@here")
+
+	case strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://"):
+		raw := patternURL.FindString(s)
+		if raw == "" {
+			return nil, 0
+		}
+		return &ContentToken{Type: ContentTokenURL, Raw: raw, Text: raw}, len(raw)
+	}
+
+	for _, span := range markdownSpans {
+		if !strings.HasPrefix(s, span.delim) {
+			continue
+		}
+		end := strings.Index(s[len(span.delim):], span.delim)
+		if end <= 0 {
+			return nil, 0
+		}
+		raw := s[:end+2*len(span.delim)]
+		inner := s[len(span.delim) : end+len(span.delim)]
+		return &ContentToken{Type: span.typ, Raw: raw, Children: ParseContent(inner)}, len(raw)
+	}
+
+	return nil, 0
+}
+
+// parseAngleToken parses the tokens enclosed in angle brackets.
+func parseAngleToken(s string) (*ContentToken, int) {
+	if m := patternUserMention.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenUserMention, Raw: m[0], ID: m[1]}, len(m[0])
+	}
+	if m := patternRoleMention.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenRoleMention, Raw: m[0], ID: m[1]}, len(m[0])
+	}
+	if m := patternChannelMention.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenChannelMention, Raw: m[0], ID: m[1]}, len(m[0])
+	}
+	if m := patternCommandMention.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenSlashCommandMention, Raw: m[0], Name: m[1], ID: m[2]}, len(m[0])
+	}
+	if m := patternCustomEmoji.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenEmoji, Raw: m[0], Animated: m[1] == "a", Name: m[2], ID: m[3]}, len(m[0])
+	}
+	if m := patternTimestamp.FindStringSubmatch(s); m != nil {
+		sec, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, 0
+		}
+		return &ContentToken{Type: ContentTokenTimestamp, Raw: m[0], Time: time.Unix(sec, 0), Style: TimestampStyle(m[2])}, len(m[0])
+	}
+	if m := patternEnclosedURL.FindStringSubmatch(s); m != nil {
+		return &ContentToken{Type: ContentTokenURL, Raw: m[0], Text: m[1]}, len(m[0])
+	}
+	return nil, 0
+}
+
+// isMarkdownPunct reports whether c can be escaped with a backslash.
+func isMarkdownPunct(c byte) bool {
+	return strings.IndexByte("\\*_~`|>#[]:<@", c) >= 0
+}
@@ -0,0 +1,82 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	in := "**bold** _it_ `code` ||spoiler|| \\"
+	escaped := EscapeMarkdown(in)
+
+	tokens := ParseContent(escaped)
+	if len(tokens) != 1 || tokens[0].Type != ContentTokenText {
+		t.Fatalf("escaped markdown parsed into %d tokens, want a single text token", len(tokens))
+	}
+	if tokens[0].Text != in {
+		t.Errorf("escaped text: got %q, want %q", tokens[0].Text, in)
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Unix(1618953630, 0)
+	if got, want := FormatTimestamp(ts, TimestampStyleRelativeTime), "<t:1618953630:R>"; got != want {
+		t.Errorf("FormatTimestamp: got %q, want %q", got, want)
+	}
+	if got, want := FormatTimestamp(ts, TimestampStyleDefault), "<t:1618953630>"; got != want {
+		t.Errorf("FormatTimestamp: got %q, want %q", got, want)
+	}
+}
+
+func TestParseContent(t *testing.T) {
+	content := "hi <@!123> **see <#456>** at <t:1618953630:R> " +
+		"<a:party:789> https://discord.com/developers. " + FormatSlashCommandMention("config set", "42") +
+		" " + FormatCodeBlock("go", "fmt.Println()")
+
+	tokens := ParseContent(content)
+
+	want := []ContentTokenType{
+		ContentTokenText, ContentTokenUserMention, ContentTokenText, ContentTokenBold,
+		ContentTokenText, ContentTokenTimestamp, ContentTokenText, ContentTokenEmoji,
+		ContentTokenText, ContentTokenURL, ContentTokenText, ContentTokenSlashCommandMention,
+		ContentTokenText, ContentTokenCodeBlock,
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("ParseContent returned %d tokens, want %d", len(tokens), len(want))
+	}
+	for i, typ := range want {
+		if tokens[i].Type != typ {
+			t.Errorf("token %d (%q): got type %d, want %d", i, tokens[i].Raw, tokens[i].Type, typ)
+		}
+	}
+
+	var raw string
+	for _, token := range tokens {
+		raw += token.Raw
+	}
+	if raw != content {
+		t.Errorf("raw tokens do not add up to the content: got %q", raw)
+	}
+
+	if tokens[1].ID != "123" {
+		t.Errorf("user mention ID: got %q, want %q", tokens[1].ID, "123")
+	}
+	if bold := tokens[3].Children; len(bold) != 2 || bold[1].Type != ContentTokenChannelMention {
+		t.Errorf("bold span children not parsed: %+v", bold)
+	}
+	if tokens[5].Style != TimestampStyleRelativeTime || tokens[5].Time.Unix() != 1618953630 {
+		t.Errorf("timestamp: got %v %q", tokens[5].Time, tokens[5].Style)
+	}
+	if e := tokens[7]; !e.Animated || e.Name != "party" || e.ID != "789" {
+		t.Errorf("emoji: got %+v", e)
+	}
+	if tokens[9].Text != "https://discord.com/developers" {
+		t.Errorf("URL: got %q", tokens[9].Text)
+	}
+	if c := tokens[11]; c.Name != "config set" || c.ID != "42" {
+		t.Errorf("slash command mention: got %+v", c)
+	}
+	if c := tokens[13]; c.Language != "go" || c.Text != "fmt.Println()" {
+		t.Errorf("code block: got language %q, text %q", c.Language, c.Text)
+	}
+}
@@ -0,0 +1,190 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the gateway close codes and the policy deciding
+// whether to resume, re-identify or give up after a disconnect.
+
+package discordgo
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// GatewayCloseCode is a close code sent by the Discord gateway.
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes
+type GatewayCloseCode int
+
+// Gateway close codes.
+const (
+	GatewayCloseUnknownError         GatewayCloseCode = 4000
+	GatewayCloseUnknownOpcode        GatewayCloseCode = 4001
+	GatewayCloseDecodeError          GatewayCloseCode = 4002
+	GatewayCloseNotAuthenticated     GatewayCloseCode = 4003
+	GatewayCloseAuthenticationFailed GatewayCloseCode = 4004
+	GatewayCloseAlreadyAuthenticated GatewayCloseCode = 4005
+	GatewayCloseInvalidSeq           GatewayCloseCode = 4007
+	GatewayCloseRateLimited          GatewayCloseCode = 4008
+	GatewayCloseSessionTimedOut      GatewayCloseCode = 4009
+	GatewayCloseInvalidShard         GatewayCloseCode = 4010
+	GatewayCloseShardingRequired     GatewayCloseCode = 4011
+	GatewayCloseInvalidAPIVersion    GatewayCloseCode = 4012
+	GatewayCloseInvalidIntents       GatewayCloseCode = 4013
+	GatewayCloseDisallowedIntents    GatewayCloseCode = 4014
+)
+
+func (c GatewayCloseCode) String() string {
+	switch c {
+	case GatewayCloseUnknownError:
+		return "unknown error"
+	case GatewayCloseUnknownOpcode:
+		return "unknown opcode"
+	case GatewayCloseDecodeError:
+		return "decode error"
+	case GatewayCloseNotAuthenticated:
+		return "not authenticated"
+	case GatewayCloseAuthenticationFailed:
+		return "authentication failed"
+	case GatewayCloseAlreadyAuthenticated:
+		return "already authenticated"
+	case GatewayCloseInvalidSeq:
+		return "invalid seq"
+	case GatewayCloseRateLimited:
+		return "rate limited"
+	case GatewayCloseSessionTimedOut:
+		return "session timed out"
+	case GatewayCloseInvalidShard:
+		return "invalid shard"
+	case GatewayCloseShardingRequired:
+		return "sharding required"
+	case GatewayCloseInvalidAPIVersion:
+		return "invalid API version"
+	case GatewayCloseInvalidIntents:
+		return "invalid intent(s)"
+	case GatewayCloseDisallowedIntents:
+		return "disallowed intent(s)"
+	}
+	return fmt.Sprintf("GatewayCloseCode(%d)", int(c))
+}
+
+// GatewayCloseAction is what the session does after the gateway closed
+// the connection.
+type GatewayCloseAction int
+
+// Valid GatewayCloseAction values
+const (
+	// GatewayCloseResume reconnects and resumes the session, so no events
+	// are missed.
+	GatewayCloseResume GatewayCloseAction = iota
+
+	// GatewayCloseReidentify reconnects with a new session.
+	GatewayCloseReidentify
+
+	// GatewayCloseFatal does not reconnect, as reconnecting would fail
+	// the same way. A GatewayFatalError event is emitted instead.
+	GatewayCloseFatal
+)
+
+// Action returns what the session does after the gateway closed the
+// connection with the code. Codes which are not Discord close codes,
+// e.g. for network errors, resume.
+func (c GatewayCloseCode) Action() GatewayCloseAction {
+	switch c {
+	case GatewayCloseNotAuthenticated, GatewayCloseInvalidSeq, GatewayCloseSessionTimedOut:
+		return GatewayCloseReidentify
+	case GatewayCloseAuthenticationFailed, GatewayCloseInvalidShard, GatewayCloseShardingRequired,
+		GatewayCloseInvalidAPIVersion, GatewayCloseInvalidIntents, GatewayCloseDisallowedIntents:
+		return GatewayCloseFatal
+	}
+	return GatewayCloseResume
+}
+
+// GatewayCloseError is returned by Open, and passed to a GatewayFatalError
+// event, when the gateway closed the connection.
+type GatewayCloseError struct {
+	Code GatewayCloseCode
+	Text string
+}
+
+func (e *GatewayCloseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("gateway closed the connection: %d %s", int(e.Code), e.Code)
+	}
+	return fmt.Sprintf("gateway closed the connection: %d %s: %s", int(e.Code), e.Code, e.Text)
+}
+
+// Fatal reports whether the session gives up after the error.
+func (e *GatewayCloseError) Fatal() bool {
+	return e.Code.Action() == GatewayCloseFatal
+}
+
+// errInvalidSessionResumable is returned by onEvent for an Op9 Invalid
+// Session which allows to resume.
+var errInvalidSessionResumable = errors.New("gateway session is invalid, but resumable")
+
+// gatewayCloseError converts a websocket close error to a
+// *GatewayCloseError and returns other errors as they are.
+func gatewayCloseError(err error) error {
+	var cerr *websocket.CloseError
+	if errors.As(err, &cerr) {
+		return &GatewayCloseError{Code: GatewayCloseCode(cerr.Code), Text: cerr.Text}
+	}
+	return err
+}
+
+// gatewayCloseAction returns what the session does after err, which
+// closed the gateway connection.
+func gatewayCloseAction(err error) GatewayCloseAction {
+	if cerr, ok := err.(*GatewayCloseError); ok {
+		return cerr.Code.Action()
+	}
+	return GatewayCloseResume
+}
+
+// resetSession forgets the gateway session, so the next Open identifies
+// instead of resuming. It does not lock the session, as it is called by
+// onEvent, which also runs inside Open.
+func (s *Session) resetSession() {
+	s.gatewaySessionMu.Lock()
+	s.sessionID = ""
+	s.resumeGatewayURL = ""
+	s.gatewaySessionMu.Unlock()
+	atomic.StoreInt64(s.sequence, 0)
+}
+
+// handleGatewayClose closes the session after err closed the gateway
+// connection, and reconnects or emits a GatewayFatalError event depending
+// on the close code.
+func (s *Session) handleGatewayClose(err error) {
+	action := gatewayCloseAction(err)
+
+	// Discord invalidates the session when closing with a normal
+	// closure, so use another code to be able to resume.
+	closeCode := websocket.CloseNormalClosure
+	if action == GatewayCloseResume {
+		closeCode = websocket.CloseServiceRestart
+	}
+	if cerr := s.CloseWithCode(closeCode); cerr != nil {
+		s.log(LogWarning, "error closing session connection, %s", cerr)
+	}
+
+	switch action {
+	case GatewayCloseFatal:
+		s.log(LogError, "not reconnecting to gateway after fatal error, %s", err)
+		s.handleEvent(gatewayFatalErrorEventType, &GatewayFatalError{err.(*GatewayCloseError)})
+		return
+	case GatewayCloseReidentify:
+		s.log(LogInformational, "gateway session is invalid, identifying on reconnect")
+		s.resetSession()
+	}
+
+	s.log(LogInformational, "calling reconnect() now")
+	s.reconnect()
+}
@@ -0,0 +1,82 @@
+package discordgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGatewayCloseCodeAction(t *testing.T) {
+	tests := []struct {
+		code GatewayCloseCode
+		want GatewayCloseAction
+	}{
+		{GatewayCloseUnknownError, GatewayCloseResume},
+		{GatewayCloseRateLimited, GatewayCloseResume},
+		{GatewayCloseInvalidSeq, GatewayCloseReidentify},
+		{GatewayCloseSessionTimedOut, GatewayCloseReidentify},
+		{GatewayCloseAuthenticationFailed, GatewayCloseFatal},
+		{GatewayCloseShardingRequired, GatewayCloseFatal},
+		{GatewayCloseDisallowedIntents, GatewayCloseFatal},
+		{websocket.CloseAbnormalClosure, GatewayCloseResume},
+	}
+	for _, tt := range tests {
+		if got := tt.code.Action(); got != tt.want {
+			t.Errorf("%d: got action %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+// testGatewayServer starts a gateway which says hello, answers the first
+// identify or resume with READY and then closes with closeCode.
+func testGatewayServer(closeCode int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]interface{}{"op": 10, "d": map[string]interface{}{"heartbeat_interval": 45000}})
+		conn.ReadMessage()
+		conn.WriteJSON(map[string]interface{}{"op": 0, "s": 1, "t": "READY", "d": map[string]interface{}{"session_id": "session"}})
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, "closed by test"))
+		time.Sleep(100 * time.Millisecond)
+	}))
+}
+
+func TestGatewayFatalClose(t *testing.T) {
+	server := testGatewayServer(int(GatewayCloseAuthenticationFailed))
+	defer server.Close()
+
+	s, _ := New("Bot token")
+	s.gateway = "ws" + strings.TrimPrefix(server.URL, "http")
+	s.IdentifyLimiter = NewIdentifyLimiter(SessionInformation{})
+
+	fatal := make(chan *GatewayFatalError, 1)
+	s.AddHandler(func(s *Session, e *GatewayFatalError) { fatal <- e })
+
+	if err := s.Open(); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	select {
+	case e := <-fatal:
+		if e.Code != GatewayCloseAuthenticationFailed || !e.Fatal() {
+			t.Errorf("got close code %d, want %d", e.Code, GatewayCloseAuthenticationFailed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no GatewayFatalError event after a fatal close code")
+	}
+
+	s.RLock()
+	reconnected := s.wsConn != nil
+	s.RUnlock()
+	if reconnected {
+		t.Errorf("session reconnected after a fatal close code")
+	}
+}
@@ -0,0 +1,252 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the rate limiter for commands sent to the gateway.
+
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrGatewayRateLimited is returned by gateway commands which can not be
+// sent before Session.GatewayRateLimitTimeout because of the gateway rate
+// limit.
+var ErrGatewayRateLimited = errors.New("gateway command rate limit exceeded")
+
+// Gateway rate limit, Discord disconnects a session which sends more than
+// GatewayCommandLimit commands in GatewayCommandInterval.
+const (
+	GatewayCommandLimit    = 120
+	GatewayCommandInterval = 60 * time.Second
+
+	// GatewayCommandsReserved is the part of the limit which is reserved
+	// for heartbeats, identify and resume, so they can always be sent.
+	GatewayCommandsReserved = 5
+)
+
+// GatewayCommandPriority is the priority of a command in the gateway send
+// queue.
+type GatewayCommandPriority int
+
+// Valid GatewayCommandPriority values
+const (
+	// GatewayPriorityNormal is the priority of commands sent by the user,
+	// like UpdateStatusComplex and RequestGuildMembers.
+	GatewayPriorityNormal GatewayCommandPriority = iota
+
+	// GatewayPriorityHigh is the priority of heartbeats, identify and
+	// resume. They are sent before any waiting normal command and may use
+	// the reserved part of the limit.
+	GatewayPriorityHigh
+)
+
+// gatewayRateLimiter queues the commands sent to the gateway, so at most
+// GatewayCommandLimit commands are sent in any GatewayCommandInterval.
+// Its zero value is ready to use.
+type gatewayRateLimiter struct {
+	sync.Mutex
+
+	// The times of the commands sent in the last interval, oldest first.
+	sent []time.Time
+
+	// The tickets of the waiting commands per priority, in order.
+	queues [2][]uint64
+
+	// The last ticket given to a waiting command.
+	ticket uint64
+
+	// Closed and replaced when a command is sent or stops waiting.
+	changed chan struct{}
+}
+
+// reset forgets the sent commands, as the limit applies per connection.
+func (l *gatewayRateLimiter) reset() {
+	l.Lock()
+	l.sent = nil
+	l.broadcast()
+	l.Unlock()
+}
+
+// remaining returns the number of commands with the priority which can be
+// sent without waiting.
+func (l *gatewayRateLimiter) remaining(priority GatewayCommandPriority) int {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	return l.capacity(priority) - len(l.sent)
+}
+
+// wait blocks until a command with the priority may be sent and counts it
+// as sent. It returns ErrGatewayRateLimited if the command has to wait and
+// block is false, and the context error if ctx is done before.
+func (l *gatewayRateLimiter) wait(ctx context.Context, priority GatewayCommandPriority, block bool) error {
+	l.Lock()
+	l.ticket++
+	w := l.ticket
+	l.queues[priority] = append(l.queues[priority], w)
+	for {
+		now := time.Now()
+		l.prune(now)
+
+		next := l.next() == w
+		if next && len(l.sent) < l.capacity(priority) {
+			l.dequeue(priority, w)
+			l.sent = append(l.sent, now)
+			l.broadcast()
+			l.Unlock()
+			return nil
+		}
+
+		if !block {
+			l.dequeue(priority, w)
+			l.broadcast()
+			l.Unlock()
+			return ErrGatewayRateLimited
+		}
+
+		// The first in line waits for the oldest command to leave the
+		// interval, the others for the queue to change.
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if next {
+			timer = time.NewTimer(l.sent[len(l.sent)-l.capacity(priority)].Add(GatewayCommandInterval).Sub(now))
+			expired = timer.C
+		}
+		if l.changed == nil {
+			l.changed = make(chan struct{})
+		}
+		changed := l.changed
+		l.Unlock()
+
+		var err error
+		select {
+		case <-expired:
+		case <-changed:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		l.Lock()
+		if err != nil {
+			l.dequeue(priority, w)
+			l.broadcast()
+			l.Unlock()
+			return err
+		}
+	}
+}
+
+// capacity returns the number of commands with the priority which may be
+// sent in an interval.
+func (l *gatewayRateLimiter) capacity(priority GatewayCommandPriority) int {
+	if priority == GatewayPriorityHigh {
+		return GatewayCommandLimit
+	}
+	return GatewayCommandLimit - GatewayCommandsReserved
+}
+
+// prune forgets the commands sent before the last interval.
+func (l *gatewayRateLimiter) prune(now time.Time) {
+	i := 0
+	for i < len(l.sent) && now.Sub(l.sent[i]) >= GatewayCommandInterval {
+		i++
+	}
+	l.sent = l.sent[i:]
+}
+
+// next returns the ticket of the waiting command which is sent next, or
+// 0 if none is waiting.
+func (l *gatewayRateLimiter) next() uint64 {
+	for p := GatewayPriorityHigh; p >= GatewayPriorityNormal; p-- {
+		if len(l.queues[p]) > 0 {
+			return l.queues[p][0]
+		}
+	}
+	return 0
+}
+
+func (l *gatewayRateLimiter) dequeue(priority GatewayCommandPriority, w uint64) {
+	q := l.queues[priority]
+	for i := range q {
+		if q[i] == w {
+			l.queues[priority] = append(q[:i:i], q[i+1:]...)
+			return
+		}
+	}
+}
+
+// broadcast wakes up all waiting commands.
+func (l *gatewayRateLimiter) broadcast() {
+	if l.changed != nil {
+		close(l.changed)
+		l.changed = nil
+	}
+}
+
+// GatewayCommandsRemaining returns the number of commands which can be
+// sent to the gateway right now without waiting for the rate limit.
+func (s *Session) GatewayCommandsRemaining() int {
+	return s.gatewayLimiter.remaining(GatewayPriorityNormal)
+}
+
+// gatewayWrite sends a command of normal priority to the gateway, waiting
+// for the rate limit as configured by GatewayRateLimitTimeout.
+func (s *Session) gatewayWrite(v interface{}) error {
+	s.RLock()
+	open := s.wsConn != nil
+	timeout := s.GatewayRateLimitTimeout
+	s.RUnlock()
+	if !open {
+		return ErrWSNotFound
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := s.gatewayLimiter.wait(ctx, GatewayPriorityNormal, timeout >= 0)
+	if err == context.DeadlineExceeded {
+		err = ErrGatewayRateLimited
+	}
+	if err != nil {
+		return err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	if s.wsConn == nil {
+		return ErrWSNotFound
+	}
+
+	s.wsMutex.Lock()
+	defer s.wsMutex.Unlock()
+	return s.wsConn.WriteJSON(v)
+}
+
+// gatewayWritePriority sends a heartbeat, identify or resume to wsConn.
+// It does not lock the session, as it is called inside Open.
+func (s *Session) gatewayWritePriority(wsConn *websocket.Conn, v interface{}) error {
+	if err := s.gatewayLimiter.wait(context.Background(), GatewayPriorityHigh, true); err != nil {
+		return err
+	}
+
+	s.wsMutex.Lock()
+	defer s.wsMutex.Unlock()
+	return wsConn.WriteJSON(v)
+}
@@ -0,0 +1,100 @@
+package discordgo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testFullGatewayRateLimiter(oldest time.Time) *gatewayRateLimiter {
+	l := &gatewayRateLimiter{}
+	l.sent = append(l.sent, oldest)
+	for len(l.sent) < GatewayCommandLimit {
+		l.sent = append(l.sent, time.Now())
+	}
+	return l
+}
+
+func TestGatewayRateLimiterReserved(t *testing.T) {
+	l := &gatewayRateLimiter{}
+	for i := 0; i < GatewayCommandLimit-GatewayCommandsReserved; i++ {
+		if err := l.wait(context.Background(), GatewayPriorityNormal, false); err != nil {
+			t.Fatalf("command %d: got error %v", i, err)
+		}
+	}
+
+	if err := l.wait(context.Background(), GatewayPriorityNormal, false); err != ErrGatewayRateLimited {
+		t.Errorf("normal command over the limit: got error %v, want %v", err, ErrGatewayRateLimited)
+	}
+	if err := l.wait(context.Background(), GatewayPriorityHigh, false); err != nil {
+		t.Errorf("heartbeat in the reserved capacity: got error %v", err)
+	}
+}
+
+func TestGatewayRateLimiterPriority(t *testing.T) {
+	// The oldest command leaves the interval in 100ms, which frees one
+	// command, for the heartbeat which waits after the normal command.
+	l := testFullGatewayRateLimiter(time.Now().Add(100*time.Millisecond - GatewayCommandInterval))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	done := make(chan GatewayCommandPriority, 2)
+	go func() {
+		if l.wait(ctx, GatewayPriorityNormal, true) == nil {
+			done <- GatewayPriorityNormal
+		} else {
+			done <- -1
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	go func() {
+		l.wait(context.Background(), GatewayPriorityHigh, true)
+		done <- GatewayPriorityHigh
+	}()
+
+	if p := <-done; p != GatewayPriorityHigh {
+		t.Errorf("first sent command: got priority %d, want %d", p, GatewayPriorityHigh)
+	}
+	if p := <-done; p != -1 {
+		t.Errorf("normal command was sent within the reserved capacity")
+	}
+}
+
+func TestGatewayRateLimiterQueue(t *testing.T) {
+	// Eight commands leave the interval in 100ms, which frees enough for all
+	// the waiting commands, in the order of the queue.
+	l := &gatewayRateLimiter{}
+	oldest := time.Now().Add(100*time.Millisecond - GatewayCommandInterval)
+	for len(l.sent) < GatewayCommandLimit {
+		if len(l.sent) < 8 {
+			l.sent = append(l.sent, oldest)
+		} else {
+			l.sent = append(l.sent, time.Now())
+		}
+	}
+
+	sent := make(chan string, 3)
+	send := func(name string, priority GatewayCommandPriority) {
+		if err := l.wait(context.Background(), priority, true); err != nil {
+			t.Errorf("%s: got error %v", name, err)
+		}
+		sent <- name
+	}
+	go send("first", GatewayPriorityNormal)
+	time.Sleep(20 * time.Millisecond)
+	go send("second", GatewayPriorityNormal)
+	time.Sleep(20 * time.Millisecond)
+	go send("heartbeat", GatewayPriorityHigh)
+
+	for _, want := range []string{"heartbeat", "first", "second"} {
+		select {
+		case got := <-sent:
+			if got != want {
+				t.Errorf("got %s sent, want %s", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not sent", want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains functions to save and restore the gateway session,
+// to resume it in another process.
+
+package discordgo
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrGatewaySessionShard is returned by RestoreGatewaySession when the
+// gateway session belongs to another shard than the session.
+var ErrGatewaySessionShard = errors.New("gateway session belongs to another shard")
+
+// GatewaySession is the data needed to resume a gateway session, e.g.
+// after a restart of the process. Discord only allows to resume for a
+// short time after the connection was closed, and not at all after a
+// normal closure, so a process which wants its session to be resumed
+// must close it with CloseWithCode(websocket.CloseServiceRestart).
+type GatewaySession struct {
+	ShardID          int    `json:"shard_id"`
+	ShardCount       int    `json:"shard_count"`
+	SessionID        string `json:"session_id"`
+	Sequence         int64  `json:"sequence"`
+	ResumeGatewayURL string `json:"resume_gateway_url"`
+}
+
+// GatewaySession returns the data needed to resume the current gateway
+// session. It should be called after the connection was closed, so the
+// sequence number does not change anymore. The SessionID is empty if the
+// session can not be resumed.
+func (s *Session) GatewaySession() *GatewaySession {
+	s.RLock()
+	defer s.RUnlock()
+	s.gatewaySessionMu.RLock()
+	defer s.gatewaySessionMu.RUnlock()
+
+	return &GatewaySession{
+		ShardID:          s.ShardID,
+		ShardCount:       s.ShardCount,
+		SessionID:        s.sessionID,
+		Sequence:         atomic.LoadInt64(s.sequence),
+		ResumeGatewayURL: s.resumeGatewayURL,
+	}
+}
+
+// RestoreGatewaySession makes the next Open resume the given gateway
+// session instead of identifying. Discord answers with an Invalid Session
+// if it can not be resumed anymore, after which the session identifies.
+// To restore the state too, see State.Restore.
+// gs : The gateway session, as returned by GatewaySession.
+func (s *Session) RestoreGatewaySession(gs *GatewaySession) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.wsConn != nil {
+		return ErrWSAlreadyOpen
+	}
+	if gs.ShardID != s.ShardID || gs.ShardCount != s.ShardCount {
+		return ErrGatewaySessionShard
+	}
+
+	s.gatewaySessionMu.Lock()
+	s.sessionID = gs.SessionID
+	s.resumeGatewayURL = gs.ResumeGatewayURL
+	s.gatewaySessionMu.Unlock()
+	atomic.StoreInt64(s.sequence, gs.Sequence)
+	return nil
+}
@@ -0,0 +1,96 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestRestoreGatewaySession(t *testing.T) {
+	resumes := make(chan resumePacket, 1)
+	resumeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]interface{}{"op": 10, "d": map[string]interface{}{"heartbeat_interval": 45000}})
+		var p resumePacket
+		conn.ReadJSON(&p)
+		resumes <- p
+		conn.WriteJSON(map[string]interface{}{"op": 0, "s": 43, "t": "RESUMED", "d": map[string]interface{}{}})
+		conn.ReadMessage()
+	}))
+	defer resumeServer.Close()
+
+	var gs GatewaySession
+	data := `{"shard_id": 0, "shard_count": 1, "session_id": "session", "sequence": 42, "resume_gateway_url": "ws` + strings.TrimPrefix(resumeServer.URL, "http") + `"}`
+	if err := json.Unmarshal([]byte(data), &gs); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+
+	s, _ := New("Bot token")
+	s.gateway = "ws://127.0.0.1:1"
+	if err := s.RestoreGatewaySession(&gs); err != nil {
+		t.Fatalf("RestoreGatewaySession returned error: %v", err)
+	}
+	if err := s.Open(); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer s.Close()
+
+	p := <-resumes
+	if p.Op != 6 || p.Data.SessionID != "session" || p.Data.Sequence != 42 {
+		t.Errorf("got resume packet %+v, want session and sequence 42", p)
+	}
+
+	if err := s.RestoreGatewaySession(&gs); err != ErrWSAlreadyOpen {
+		t.Errorf("restore on an open session: got error %v, want %v", err, ErrWSAlreadyOpen)
+	}
+}
+
+func TestResetSessionWhileListening(t *testing.T) {
+	s, _ := New("Bot token")
+	s.RestoreGatewaySession(&GatewaySession{SessionID: "session", Sequence: 5, ResumeGatewayURL: "wss://resume"})
+
+	// An Invalid Session resets the session on the listen goroutine, while
+	// the session is not locked.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.resetSession()
+	}()
+	s.GatewaySession()
+	<-done
+
+	if gs := s.GatewaySession(); gs.SessionID != "" || gs.Sequence != 0 || gs.ResumeGatewayURL != "" {
+		t.Errorf("got gateway session %+v after the reset, want none", gs)
+	}
+}
+
+func TestStateSnapshot(t *testing.T) {
+	state := NewState()
+	state.GuildAdd(&Guild{ID: "guild", Channels: []*Channel{{ID: "channel", GuildID: "guild"}}})
+	state.MemberAdd(&Member{GuildID: "guild", User: &User{ID: "user"}})
+
+	data, err := state.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot returned error: %v", err)
+	}
+
+	restored := NewState()
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if _, err := restored.Channel("channel"); err != nil {
+		t.Errorf("restored state lacks channel: %v", err)
+	}
+	if _, err := restored.Member("guild", "user"); err != nil {
+		t.Errorf("restored state lacks member: %v", err)
+	}
+}
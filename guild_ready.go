@@ -0,0 +1,200 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains code which tracks the availability of guilds and
+// emits the GuildAvailable, GuildJoin and GuildsReady events.
+
+package discordgo
+
+import (
+	"sort"
+	"time"
+)
+
+// GuildsReady reports whether the GuildCreate events of all guilds in the
+// READY packet were received, or Session.GuildsReadyTimeout passed.
+func (s *State) GuildsReady() bool {
+	if s == nil {
+		return false
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.guildsReady
+}
+
+// GuildUnavailable reports whether a guild of the bot is unavailable,
+// because it was not received since READY yet or because of an outage.
+// guildID   : The ID of a Guild.
+func (s *State) GuildUnavailable(guildID string) bool {
+	if s == nil {
+		return false
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	available, ok := s.guildAvailable[guildID]
+	return ok && !available
+}
+
+// UnavailableGuilds returns the sorted IDs of the unavailable guilds of
+// the bot.
+func (s *State) UnavailableGuilds() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	var ids []string
+	for id, available := range s.guildAvailable {
+		if !available {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// readyGuilds starts tracking the guilds of a READY packet. It returns
+// whether all guilds are ready, and the number of the READY packet to
+// pass to guildsReadyTimeout.
+func (s *State) readyGuilds(r *Ready) (ready bool, readyCount int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.guildAvailable = make(map[string]bool)
+	s.pendingGuilds = make(map[string]bool)
+	for _, g := range r.Guilds {
+		s.guildAvailable[g.ID] = !g.Unavailable
+		if g.Unavailable {
+			s.pendingGuilds[g.ID] = true
+		}
+	}
+	s.readyCount++
+	s.guildsReady = len(s.pendingGuilds) == 0
+	return s.guildsReady, s.readyCount
+}
+
+// guildCreated tracks a GuildCreate event. It returns whether the guild
+// is available again after an outage, whether the bot joined it, and
+// whether it was the last guild of the READY packet.
+func (s *State) guildCreated(guildID string) (available, joined, ready bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.guildAvailable == nil {
+		s.guildAvailable = make(map[string]bool)
+	}
+	wasAvailable, known := s.guildAvailable[guildID]
+	s.guildAvailable[guildID] = true
+
+	if s.pendingGuilds[guildID] {
+		delete(s.pendingGuilds, guildID)
+		return false, false, s.checkGuildsReady()
+	}
+	return known && !wasAvailable, !known, false
+}
+
+// guildDeleted tracks a GuildDelete event. It returns whether it removed
+// the last missing guild of the READY packet. Discord sends an unavailable
+// GuildDelete for the guilds of the READY packet which are in an outage,
+// they stay unavailable but are not waited for anymore.
+func (s *State) guildDeleted(guildID string, unavailable bool) (ready bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if unavailable {
+		if s.guildAvailable != nil {
+			s.guildAvailable[guildID] = false
+		}
+		if s.pendingGuilds[guildID] {
+			delete(s.pendingGuilds, guildID)
+			return s.checkGuildsReady()
+		}
+		return false
+	}
+
+	delete(s.guildAvailable, guildID)
+	if s.pendingGuilds[guildID] {
+		delete(s.pendingGuilds, guildID)
+		return s.checkGuildsReady()
+	}
+	return false
+}
+
+// guildsReadyTimeout gives up waiting for the guilds of the READY packet
+// with the given number. It returns the guilds which are still missing,
+// and false if the guilds were ready already.
+func (s *State) guildsReadyTimeout(readyCount int) (missing []string, ok bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.readyCount != readyCount || s.guildsReady {
+		return nil, false
+	}
+
+	for id := range s.pendingGuilds {
+		missing = append(missing, id)
+	}
+	sort.Strings(missing)
+	s.pendingGuilds = nil
+	s.guildsReady = true
+	return missing, true
+}
+
+// checkGuildsReady marks the guilds as ready once none are pending, and
+// reports whether they just became ready. The state must be locked.
+func (s *State) checkGuildsReady() bool {
+	if s.guildsReady || len(s.pendingGuilds) > 0 {
+		return false
+	}
+	s.guildsReady = true
+	return true
+}
+
+// onGuildReadiness tracks the availability of guilds in the state and
+// emits the synthetic guild events which follow from an event.
+func (s *Session) onGuildReadiness(i interface{}) {
+	if s.State == nil {
+		return
+	}
+
+	switch t := i.(type) {
+	case *Ready:
+		ready, readyCount := s.State.readyGuilds(t)
+		if ready {
+			s.handleEvent(guildsReadyEventType, &GuildsReady{})
+			return
+		}
+		if s.GuildsReadyTimeout > 0 {
+			time.AfterFunc(s.GuildsReadyTimeout, func() {
+				if missing, ok := s.State.guildsReadyTimeout(readyCount); ok {
+					s.log(LogWarning, "guilds %v are still unavailable after %v", missing, s.GuildsReadyTimeout)
+					s.handleEvent(guildsReadyEventType, &GuildsReady{Unavailable: missing})
+				}
+			})
+		}
+	case *GuildCreate:
+		available, joined, ready := s.State.guildCreated(t.ID)
+		switch {
+		case available:
+			s.handleEvent(guildAvailableEventType, &GuildAvailable{t.Guild})
+		case joined:
+			s.handleEvent(guildJoinEventType, &GuildJoin{t.Guild})
+		case ready:
+			s.handleEvent(guildsReadyEventType, &GuildsReady{Unavailable: s.State.UnavailableGuilds()})
+		}
+	case *GuildDelete:
+		if s.State.guildDeleted(t.ID, t.Unavailable) {
+			s.handleEvent(guildsReadyEventType, &GuildsReady{Unavailable: s.State.UnavailableGuilds()})
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package discordgo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGuildReadiness(t *testing.T) {
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+
+	var events []string
+	s.AddHandler(func(_ *Session, e *GuildAvailable) { events = append(events, "available "+e.ID) })
+	s.AddHandler(func(_ *Session, e *GuildJoin) { events = append(events, "join "+e.ID) })
+	s.AddHandler(func(_ *Session, e *GuildsReady) { events = append(events, "ready") })
+
+	s.handleEvent(readyEventType, &Ready{Guilds: []*Guild{{ID: "a", Unavailable: true}, {ID: "b", Unavailable: true}}})
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "a"}})
+	if s.State.GuildsReady() || !s.State.GuildUnavailable("b") {
+		t.Errorf("guilds ready before guild b was received")
+	}
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "b"}})
+
+	// An outage keeps the guild in the state.
+	s.handleEvent(guildDeleteEventType, &GuildDelete{Guild: &Guild{ID: "a", Unavailable: true}})
+	if g, err := s.State.Guild("a"); err != nil || !g.Unavailable {
+		t.Errorf("unavailable guild: got %+v, %v, want the guild marked unavailable", g, err)
+	}
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "a"}})
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "c"}})
+
+	want := []string{"ready", "available a", "join c"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("got events %q, want %q", events, want)
+	}
+}
+
+func TestGuildsReadyWithOutage(t *testing.T) {
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+
+	var ready []*GuildsReady
+	s.AddHandler(func(_ *Session, e *GuildsReady) { ready = append(ready, e) })
+
+	// Guild b is in an outage at startup, which Discord reports with an
+	// unavailable GuildDelete instead of a GuildCreate.
+	s.handleEvent(readyEventType, &Ready{Guilds: []*Guild{{ID: "a", Unavailable: true}, {ID: "b", Unavailable: true}}})
+	s.handleEvent(guildDeleteEventType, &GuildDelete{Guild: &Guild{ID: "b", Unavailable: true}})
+	if s.State.GuildsReady() {
+		t.Errorf("guilds ready before guild a was received")
+	}
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "a"}})
+
+	if len(ready) != 1 || !reflect.DeepEqual(ready[0].Unavailable, []string{"b"}) {
+		t.Fatalf("got GuildsReady events %+v, want one with guild b unavailable", ready)
+	}
+	if !s.State.GuildUnavailable("b") {
+		t.Errorf("guild b is not unavailable")
+	}
+}
+
+func TestGuildsReadyTimeout(t *testing.T) {
+	s, _ := New("Bot token")
+	s.GuildsReadyTimeout = 10 * time.Millisecond
+
+	ready := make(chan *GuildsReady, 1)
+	s.AddHandler(func(_ *Session, e *GuildsReady) { ready <- e })
+
+	s.handleEvent(readyEventType, &Ready{Guilds: []*Guild{{ID: "a", Unavailable: true}}})
+	select {
+	case e := <-ready:
+		if !reflect.DeepEqual(e.Unavailable, []string{"a"}) {
+			t.Errorf("got unavailable guilds %v, want [a]", e.Unavailable)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no GuildsReady event after the timeout")
+	}
+}
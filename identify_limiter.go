@@ -0,0 +1,158 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the limiter which coordinates the identifies of the
+// sessions of a bot.
+
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSessionStartLimitExhausted is returned when a session can not
+// identify because the bot has no session starts remaining today.
+var ErrSessionStartLimitExhausted = errors.New("session start limit exhausted")
+
+// IdentifyInterval is the time Discord requires between two identifies in
+// the same rate limit bucket.
+const IdentifyInterval = 5 * time.Second
+
+// An IdentifyLimiter coordinates the identifies of all sessions of a bot.
+// Discord allows max_concurrency identifies every IdentifyInterval, one
+// per bucket shard_id % max_concurrency, and a limited number of session
+// starts per day. Implement it to share the limits between processes.
+type IdentifyLimiter interface {
+	// Wait blocks until the shard may identify and counts the identify.
+	// It returns an error wrapping ErrSessionStartLimitExhausted when no
+	// session starts remain, or the context error if ctx is done before.
+	Wait(ctx context.Context, shardID int) error
+}
+
+// LocalIdentifyLimiter is an IdentifyLimiter for the sessions of a bot in
+// the same process. Sessions which have no IdentifyLimiter share one per
+// token, with the limits of the bot given by GatewayBot.
+type LocalIdentifyLimiter struct {
+	sync.Mutex
+
+	maxConcurrency int
+
+	// The session starts, only limited when total is not zero.
+	total     int
+	remaining int
+	resetAt   time.Time
+
+	// The earliest next identify per bucket.
+	next map[int]time.Time
+}
+
+// NewIdentifyLimiter returns a LocalIdentifyLimiter for the limits given
+// in GatewayBotResponse.SessionStartLimit. With the zero value it allows
+// one identify every IdentifyInterval and does not limit session starts.
+func NewIdentifyLimiter(info SessionInformation) *LocalIdentifyLimiter {
+	l := &LocalIdentifyLimiter{
+		maxConcurrency: info.MaxConcurrency,
+		total:          info.Total,
+		remaining:      info.Remaining,
+		resetAt:        time.Now().Add(time.Duration(info.ResetAfter) * time.Millisecond),
+		next:           make(map[int]time.Time),
+	}
+	if l.maxConcurrency < 1 {
+		l.maxConcurrency = 1
+	}
+	return l
+}
+
+// Wait implements IdentifyLimiter.
+func (l *LocalIdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	bucket := shardID % l.maxConcurrency
+
+	l.Lock()
+	for {
+		now := time.Now()
+		if l.total != 0 && !now.Before(l.resetAt) {
+			l.remaining = l.total
+			l.resetAt = now.Add(24 * time.Hour)
+		}
+		if l.total != 0 && l.remaining <= 0 {
+			resetAt := l.resetAt
+			l.Unlock()
+			return fmt.Errorf("%w: resets at %s", ErrSessionStartLimitExhausted, resetAt.Format(time.RFC3339))
+		}
+
+		next := l.next[bucket]
+		if !now.Before(next) {
+			l.next[bucket] = now.Add(IdentifyInterval)
+			l.remaining--
+			l.Unlock()
+			return nil
+		}
+		l.Unlock()
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		l.Lock()
+	}
+}
+
+// defaultIdentifyLimiters are the limiters shared by the sessions without
+// an IdentifyLimiter, per token.
+var defaultIdentifyLimiters = struct {
+	sync.Mutex
+	m map[string]*defaultIdentifyLimiter
+}{m: make(map[string]*defaultIdentifyLimiter)}
+
+// defaultIdentifyLimiter is the default limiter of a token, created once
+// from the session start limit of the bot.
+type defaultIdentifyLimiter struct {
+	once sync.Once
+	l    *LocalIdentifyLimiter
+}
+
+// identifyLimiter returns the IdentifyLimiter of the session, or the
+// default one for its token. The default one gets the limits of the bot
+// from GatewayBot, or allows one identify every IdentifyInterval when they
+// can not be fetched.
+func (s *Session) identifyLimiter() IdentifyLimiter {
+	if s.IdentifyLimiter != nil {
+		return s.IdentifyLimiter
+	}
+
+	token := s.Identify.Token
+	if token == "" {
+		token = s.Token
+	}
+
+	defaultIdentifyLimiters.Lock()
+	d, ok := defaultIdentifyLimiters.m[token]
+	if !ok {
+		d = &defaultIdentifyLimiter{}
+		defaultIdentifyLimiters.m[token] = d
+	}
+	defaultIdentifyLimiters.Unlock()
+
+	d.once.Do(func() {
+		st, err := s.GatewayBot()
+		if err != nil {
+			s.log(LogWarning, "error getting the session start limit, %s", err)
+			d.l = NewIdentifyLimiter(SessionInformation{})
+			return
+		}
+		d.l = NewIdentifyLimiter(st.SessionStartLimit)
+	})
+	return d.l
+}
@@ -0,0 +1,133 @@
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLocalIdentifyLimiter(t *testing.T) {
+	l := NewIdentifyLimiter(SessionInformation{Total: 1000, Remaining: 3, ResetAfter: 3600000, MaxConcurrency: 2})
+
+	// Shards 0 and 1 are in different buckets and identify at once.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for shard := 0; shard < 2; shard++ {
+		if err := l.Wait(ctx, shard); err != nil {
+			t.Fatalf("shard %d: got error %v", shard, err)
+		}
+	}
+
+	// Shard 2 shares the bucket of shard 0 and has to wait.
+	if err := l.Wait(ctx, 2); err != context.DeadlineExceeded {
+		t.Errorf("shard 2: got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The last session start is used by shard 3 after its bucket frees up.
+	l.next[1] = time.Now()
+	if err := l.Wait(context.Background(), 3); err != nil {
+		t.Fatalf("shard 3: got error %v", err)
+	}
+	l.next[0] = time.Now()
+	if err := l.Wait(context.Background(), 0); !errors.Is(err, ErrSessionStartLimitExhausted) {
+		t.Errorf("exhausted session starts: got error %v, want %v", err, ErrSessionStartLimitExhausted)
+	}
+}
+
+func TestDefaultIdentifyLimiter(t *testing.T) {
+	var requests int32
+	s, closeServer := newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/api/v"+APIVersion+"/gateway/bot" {
+			t.Errorf("got request to %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"url": "wss://gateway.discord.gg", "shards": 1, "session_start_limit": {"total": 1000, "remaining": 0, "reset_after": 3600000, "max_concurrency": 1}}`))
+	})
+	defer closeServer()
+	s.Identify.Token = "Bot default identify limiter"
+
+	for i := 0; i < 2; i++ {
+		if err := s.identifyLimiter().Wait(context.Background(), 0); !errors.Is(err, ErrSessionStartLimitExhausted) {
+			t.Errorf("attempt %d: got error %v, want %v", i, err, ErrSessionStartLimitExhausted)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("got %d gateway bot requests, want 1", n)
+	}
+
+	// Without the session start limit, the default limiter still spaces
+	// out the identifies.
+	s, closeServer = newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer closeServer()
+	s.Identify.Token = "Bot unauthorized identify limiter"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.identifyLimiter().Wait(ctx, 0); err != nil {
+		t.Fatalf("first identify: got error %v", err)
+	}
+	if err := s.identifyLimiter().Wait(ctx, 0); err != context.DeadlineExceeded {
+		t.Errorf("second identify: got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// blockingIdentifyLimiter blocks identifies until their context is done.
+type blockingIdentifyLimiter chan struct{}
+
+func (l blockingIdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	close(l)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCloseCancelsIdentify(t *testing.T) {
+	s, _ := New("Bot token")
+	waiting := make(blockingIdentifyLimiter)
+	s.IdentifyLimiter = waiting
+
+	errc := make(chan error, 1)
+	go func() { errc <- s.waitToIdentify() }()
+	<-waiting
+	s.Close()
+
+	select {
+	case err := <-errc:
+		if err != context.Canceled {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("identify still waiting after Close")
+	}
+}
+
+// errIdentifyLimiter refuses every identify.
+type errIdentifyLimiter struct{}
+
+func (errIdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	return ErrSessionStartLimitExhausted
+}
+
+func TestOpenWaitsToIdentifyBeforeConnecting(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+	}))
+	defer server.Close()
+
+	s, _ := New("Bot token")
+	s.gateway = "ws" + strings.TrimPrefix(server.URL, "http")
+	s.IdentifyLimiter = errIdentifyLimiter{}
+	if err := s.Open(); err != ErrSessionStartLimitExhausted {
+		t.Errorf("got error %v, want %v", err, ErrSessionStartLimitExhausted)
+	}
+	if n := atomic.LoadInt32(&connections); n != 0 {
+		t.Errorf("got %d connections before the identify was allowed, want 0", n)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -39,6 +40,75 @@ type ApplicationCommand struct {
 	Version     string `json:"version,omitempty"`
 	// NOTE: Chat commands only. Otherwise it mustn't be set.
 	Options []*ApplicationCommandOption `json:"options"`
+
+	NameLocalizations        map[Locale]string `json:"name_localizations,omitempty"`
+	DescriptionLocalizations map[Locale]string `json:"description_localizations,omitempty"`
+
+	// The permissions a member needs to use the command by default.
+	// nil allows everyone, a pointer to 0 only administrators. Guild
+	// admins can change this with command permissions.
+	DefaultMemberPermissions *Permissions `json:"default_member_permissions,omitempty"`
+
+	// Whether the global command can be used in DMs, true if nil.
+	DMPermission *bool `json:"dm_permission,omitempty"`
+
+	// Whether the command is age-restricted.
+	NSFW *bool `json:"nsfw,omitempty"`
+}
+
+// ApplicationCommandPermissionType indicates whether an application
+// command permission targets a role, user or channel.
+type ApplicationCommandPermissionType uint8
+
+// Application command permission types.
+const (
+	ApplicationCommandPermissionTypeRole    ApplicationCommandPermissionType = 1
+	ApplicationCommandPermissionTypeUser    ApplicationCommandPermissionType = 2
+	ApplicationCommandPermissionTypeChannel ApplicationCommandPermissionType = 3
+)
+
+// ApplicationCommandPermissions allows or denies the use of an
+// application command to a role, user or in a channel.
+// Use the guild ID as the ID to target @everyone, and
+// ApplicationCommandPermissionsAllChannels to target all channels.
+type ApplicationCommandPermissions struct {
+	ID         string                           `json:"id"`
+	Type       ApplicationCommandPermissionType `json:"type"`
+	Permission bool                             `json:"permission"`
+}
+
+// ApplicationCommandPermissionsList is a list of application command
+// permissions, used to edit the permissions of a command.
+type ApplicationCommandPermissionsList struct {
+	Permissions []*ApplicationCommandPermissions `json:"permissions"`
+}
+
+// GuildApplicationCommandPermissions are the permissions of an
+// application command in a guild.
+type GuildApplicationCommandPermissions struct {
+	// The ID of the command, or of the application for the permissions
+	// which apply to all its commands.
+	ID            string                           `json:"id"`
+	ApplicationID string                           `json:"application_id"`
+	GuildID       string                           `json:"guild_id"`
+	Permissions   []*ApplicationCommandPermissions `json:"permissions"`
+}
+
+// ErrInvalidGuildID is returned by ApplicationCommandPermissionsAllChannels
+// for the guild ID 0.
+var ErrInvalidGuildID = errors.New("invalid guild ID 0")
+
+// ApplicationCommandPermissionsAllChannels returns the ID which targets
+// all channels of a guild in ApplicationCommandPermissions.
+func ApplicationCommandPermissionsAllChannels(guildID string) (string, error) {
+	id, err := ParseSnowflake(guildID)
+	if err != nil {
+		return "", err
+	}
+	if id == 0 {
+		return "", ErrInvalidGuildID
+	}
+	return (id - 1).String(), nil
 }
 
 // ApplicationCommandOptionType indicates the type of a slash command's option.
@@ -96,12 +166,16 @@ type ApplicationCommandOption struct {
 	// NOTE: mutually exclusive with Choices.
 	Autocomplete bool                              `json:"autocomplete"`
 	Choices      []*ApplicationCommandOptionChoice `json:"choices"`
+
+	NameLocalizations        map[Locale]string `json:"name_localizations,omitempty"`
+	DescriptionLocalizations map[Locale]string `json:"description_localizations,omitempty"`
 }
 
 // ApplicationCommandOptionChoice represents a slash command option choice.
 type ApplicationCommandOptionChoice struct {
-	Name  string      `json:"name"`
-	Value interface{} `json:"value"`
+	Name              string            `json:"name"`
+	NameLocalizations map[Locale]string `json:"name_localizations,omitempty"`
+	Value             interface{}       `json:"value"`
 }
 
 // InteractionType indicates the type of an interaction event.
@@ -152,6 +226,13 @@ type Interaction struct {
 
 	Token   string `json:"token"`
 	Version int    `json:"version"`
+
+	// The locale of the user who invoked this interaction.
+	// NOTE: this field is not filled for ping interactions.
+	Locale Locale `json:"locale"`
+	// The preferred locale of the guild in which the interaction was invoked.
+	// NOTE: this field is only filled when the interaction was invoked in a guild.
+	GuildLocale Locale `json:"guild_locale"`
 }
 
 type interaction Interaction
@@ -415,6 +496,7 @@ type InteractionResponseData struct {
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
 	Flags           uint64                  `json:"flags,omitempty"`
 	Files           []*File                 `json:"-"`
+	Attachments     []*MessageAttachment    `json:"attachments,omitempty"`
 
 	// NOTE: autocomplete interaction only.
 	Choices []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
@@ -66,3 +69,46 @@ func TestVerifyInteraction(t *testing.T) {
 		}
 	})
 }
+
+func TestApplicationCommandPermissionsEdit(t *testing.T) {
+	var method, path string
+	var got ApplicationCommandPermissionsList
+	s, closeServer := newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.Write([]byte(`{"id": "cmd", "guild_id": "175928847299117063", "permissions": []}`))
+	})
+	defer closeServer()
+
+	all, err := ApplicationCommandPermissionsAllChannels("175928847299117063")
+	if err != nil || all != "175928847299117062" {
+		t.Fatalf("ApplicationCommandPermissionsAllChannels: got %q, %v", all, err)
+	}
+	if _, err := ApplicationCommandPermissionsAllChannels("0"); err != ErrInvalidGuildID {
+		t.Errorf("ApplicationCommandPermissionsAllChannels(0): got error %v, want %v", err, ErrInvalidGuildID)
+	}
+
+	_, err = s.ApplicationCommandPermissionsEdit("app", "175928847299117063", "cmd", &ApplicationCommandPermissionsList{
+		Permissions: []*ApplicationCommandPermissions{
+			{ID: all, Type: ApplicationCommandPermissionTypeChannel, Permission: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplicationCommandPermissionsEdit returned error: %v", err)
+	}
+	if want := "/api/v8/applications/app/guilds/175928847299117063/commands/cmd/permissions"; method != "PUT" || path != want {
+		t.Errorf("got %s %s, want PUT %s", method, path, want)
+	}
+	if len(got.Permissions) != 1 || got.Permissions[0].ID != all || got.Permissions[0].Type != ApplicationCommandPermissionTypeChannel {
+		t.Errorf("got body %+v", got)
+	}
+}
+
+func TestApplicationCommandDefaultMemberPermissions(t *testing.T) {
+	admin := Permissions(0)
+	data, _ := json.Marshal(&ApplicationCommand{Name: "ban", DefaultMemberPermissions: &admin})
+	if !strings.Contains(string(data), `"default_member_permissions":"0"`) || strings.Contains(string(data), "dm_permission") {
+		t.Errorf("got %s", data)
+	}
+}
@@ -0,0 +1,295 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the documented Discord message limits and functions
+// to validate messages against them before they are sent.
+
+package discordgo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of messages, in characters or number of items.
+// https://discord.com/developers/docs/resources/channel#create-message
+const (
+	MessageLimitContent     = 2000
+	MessageLimitEmbeds      = 10
+	MessageLimitFiles       = 10
+	MessageLimitActionsRows = 5
+
+	WebhookLimitUsername = 80
+
+	EmbedLimitTitle       = 256
+	EmbedLimitDescription = 4096
+	EmbedLimitFields      = 25
+	EmbedLimitFieldName   = 256
+	EmbedLimitFieldValue  = 1024
+	EmbedLimitFooterText  = 2048
+	EmbedLimitAuthorName  = 256
+	EmbedLimitTotal       = 6000
+
+	ActionsRowLimitComponents        = 5
+	ButtonLimitLabel                 = 80
+	ComponentLimitCustomID           = 100
+	SelectMenuLimitOptions           = 25
+	SelectMenuLimitPlaceholder       = 150
+	SelectMenuOptionLimitLabel       = 100
+	SelectMenuOptionLimitValue       = 100
+	SelectMenuOptionLimitDescription = 100
+	InteractionResponseLimitChoices  = 25
+)
+
+// A LimitError describes a field of a message which exceeds a Discord limit.
+type LimitError struct {
+	// The path of the field, e.g. embeds[0].fields[3].value
+	Field string
+
+	// The limit and the actual length or number of items of the field.
+	Limit  int
+	Length int
+}
+
+// Error returns a string representation of the LimitError.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s exceeds the limit of %d (got %d)", e.Field, e.Limit, e.Length)
+}
+
+// LimitErrors is returned by the Validate methods and holds every
+// LimitError found.
+type LimitErrors []*LimitError
+
+// Error returns a string representation of all LimitErrors.
+func (e LimitErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// limitChecker collects the LimitErrors of a message.
+type limitChecker struct {
+	errs LimitErrors
+}
+
+func (c *limitChecker) check(field string, length, limit int) {
+	if length > limit {
+		c.errs = append(c.errs, &LimitError{Field: field, Limit: limit, Length: length})
+	}
+}
+
+func (c *limitChecker) text(field, s string, limit int) {
+	c.check(field, utf8.RuneCountInString(s), limit)
+}
+
+func (c *limitChecker) embed(field string, e *MessageEmbed) {
+	if e == nil {
+		return
+	}
+
+	c.text(field+".title", e.Title, EmbedLimitTitle)
+	c.text(field+".description", e.Description, EmbedLimitDescription)
+	if e.Author != nil {
+		c.text(field+".author.name", e.Author.Name, EmbedLimitAuthorName)
+	}
+	if e.Footer != nil {
+		c.text(field+".footer.text", e.Footer.Text, EmbedLimitFooterText)
+	}
+	c.check(field+".fields", len(e.Fields), EmbedLimitFields)
+	for i, f := range e.Fields {
+		c.text(fmt.Sprintf("%s.fields[%d].name", field, i), f.Name, EmbedLimitFieldName)
+		c.text(fmt.Sprintf("%s.fields[%d].value", field, i), f.Value, EmbedLimitFieldValue)
+	}
+}
+
+func (c *limitChecker) embeds(embeds []*MessageEmbed) {
+	c.check("embeds", len(embeds), MessageLimitEmbeds)
+
+	total := 0
+	for i, e := range embeds {
+		c.embed(fmt.Sprintf("embeds[%d]", i), e)
+		total += e.Length()
+	}
+	c.check("embeds (total characters)", total, EmbedLimitTotal)
+}
+
+func (c *limitChecker) components(components []MessageComponent) {
+	c.check("components", len(components), MessageLimitActionsRows)
+
+	for i, component := range components {
+		field := fmt.Sprintf("components[%d]", i)
+
+		var row ActionsRow
+		switch r := component.(type) {
+		case ActionsRow:
+			row = r
+		case *ActionsRow:
+			row = *r
+		default:
+			continue
+		}
+
+		c.check(field+".components", len(row.Components), ActionsRowLimitComponents)
+		for j, component := range row.Components {
+			c.component(fmt.Sprintf("%s.components[%d]", field, j), component)
+		}
+	}
+}
+
+func (c *limitChecker) component(field string, component MessageComponent) {
+	switch v := component.(type) {
+	case *Button:
+		c.component(field, *v)
+	case Button:
+		c.text(field+".label", v.Label, ButtonLimitLabel)
+		c.text(field+".custom_id", v.CustomID, ComponentLimitCustomID)
+	case *SelectMenu:
+		c.component(field, *v)
+	case SelectMenu:
+		c.text(field+".custom_id", v.CustomID, ComponentLimitCustomID)
+		c.text(field+".placeholder", v.Placeholder, SelectMenuLimitPlaceholder)
+		c.check(field+".options", len(v.Options), SelectMenuLimitOptions)
+		for i, o := range v.Options {
+			c.text(fmt.Sprintf("%s.options[%d].label", field, i), o.Label, SelectMenuOptionLimitLabel)
+			c.text(fmt.Sprintf("%s.options[%d].value", field, i), o.Value, SelectMenuOptionLimitValue)
+			c.text(fmt.Sprintf("%s.options[%d].description", field, i), o.Description, SelectMenuOptionLimitDescription)
+		}
+	}
+}
+
+func (c *limitChecker) err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+// Validate checks the embed against the Discord limits and returns
+// LimitErrors describing every exceeded limit, or nil.
+func (e *MessageEmbed) Validate() error {
+	c := &limitChecker{}
+	c.embed("embed", e)
+	c.check("embed (total characters)", e.Length(), EmbedLimitTotal)
+	return c.err()
+}
+
+// Validate checks the message against the Discord limits and returns
+// LimitErrors describing every exceeded limit, or nil.
+func (m *MessageSend) Validate() error {
+	c := &limitChecker{}
+	c.text("content", m.Content, MessageLimitContent)
+
+	embeds := m.Embeds
+	if m.Embed != nil {
+		embeds = append([]*MessageEmbed{m.Embed}, embeds...)
+	}
+	c.embeds(embeds)
+
+	files := len(m.Files)
+	if m.File != nil {
+		files++
+	}
+	c.check("files", files, MessageLimitFiles)
+	c.components(m.Components)
+	return c.err()
+}
+
+// Validate checks the webhook message against the Discord limits and
+// returns LimitErrors describing every exceeded limit, or nil.
+func (p *WebhookParams) Validate() error {
+	c := &limitChecker{}
+	c.text("content", p.Content, MessageLimitContent)
+	c.text("username", p.Username, WebhookLimitUsername)
+	c.embeds(p.Embeds)
+	c.check("files", len(p.Files), MessageLimitFiles)
+	c.components(p.Components)
+	return c.err()
+}
+
+// Validate checks the interaction response against the Discord limits and
+// returns LimitErrors describing every exceeded limit, or nil.
+func (d *InteractionResponseData) Validate() error {
+	c := &limitChecker{}
+	c.text("content", d.Content, MessageLimitContent)
+	c.embeds(d.Embeds)
+	c.check("files", len(d.Files), MessageLimitFiles)
+	c.components(d.Components)
+	c.check("choices", len(d.Choices), InteractionResponseLimitChoices)
+	return c.err()
+}
+
+// SplitContent splits content into parts of at most limit characters.
+// Parts are split at the last line break, or else the last space, before
+// the limit, so words are only cut if they are longer than limit.
+func SplitContent(content string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	var parts []string
+	runes := []rune(content)
+	for len(runes) > limit {
+		cut := lastIndexRune(runes[:limit], '\n')
+		if cut <= 0 {
+			cut = lastIndexRune(runes[:limit], ' ')
+		}
+		if cut <= 0 {
+			cut = limit
+		}
+
+		parts = append(parts, string(runes[:cut]))
+		runes = runes[cut:]
+
+		// Drop the separator the part was split at.
+		if len(runes) > 0 && (runes[0] == '\n' || runes[0] == ' ') {
+			runes = runes[1:]
+		}
+	}
+
+	return append(parts, string(runes))
+}
+
+func lastIndexRune(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// Split splits a message with content longer than MessageLimitContent into
+// several messages which can be sent one after another. The first message
+// keeps the reply reference, the last one the embeds, components and files.
+// A message within the limit is returned as is.
+func (m *MessageSend) Split() []*MessageSend {
+	if utf8.RuneCountInString(m.Content) <= MessageLimitContent {
+		return []*MessageSend{m}
+	}
+
+	parts := SplitContent(m.Content, MessageLimitContent)
+	st := make([]*MessageSend, len(parts))
+	for i, content := range parts {
+		st[i] = &MessageSend{
+			Content:         content,
+			TTS:             m.TTS,
+			AllowedMentions: m.AllowedMentions,
+		}
+	}
+
+	st[0].Reference = m.Reference
+
+	last := *m
+	last.Content = parts[len(parts)-1]
+	last.Reference = nil
+	st[len(st)-1] = &last
+
+	return st
+}
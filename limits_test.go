@@ -0,0 +1,89 @@
+package discordgo
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMessageSendValidate(t *testing.T) {
+	m := &MessageSend{
+		Content: "hello",
+		Embeds:  []*MessageEmbed{NewMessageEmbed().SetTitle("title").AddField("name", "value", false)},
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Validate returned error for valid message: %v", err)
+	}
+
+	// Nil embeds are skipped.
+	withNil := &MessageSend{Embeds: []*MessageEmbed{nil, m.Embeds[0]}}
+	if err := withNil.Validate(); err != nil {
+		t.Errorf("Validate returned error for message with a nil embed: %v", err)
+	}
+
+	m.Content = strings.Repeat("a", MessageLimitContent+1)
+	m.Embeds[0].AddField("name", strings.Repeat("b", EmbedLimitFieldValue+1), false)
+	m.Components = []MessageComponent{ActionsRow{Components: []MessageComponent{
+		Button{Label: strings.Repeat("c", ButtonLimitLabel+1), CustomID: "id"},
+	}}}
+
+	err := m.Validate()
+	errs, ok := err.(LimitErrors)
+	if !ok {
+		t.Fatalf("Validate returned %T, want LimitErrors", err)
+	}
+
+	want := []string{"content", "embeds[0].fields[1].value", "components[0].components[0].label"}
+	if len(errs) != len(want) {
+		t.Fatalf("Validate returned %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, field := range want {
+		if errs[i].Field != field {
+			t.Errorf("error %d: got field %q, want %q", i, errs[i].Field, field)
+		}
+	}
+}
+
+func TestMessageEmbedTruncate(t *testing.T) {
+	e := NewMessageEmbed().
+		SetTitle(strings.Repeat("t", EmbedLimitTitle+10)).
+		SetDescription(strings.Repeat("d", EmbedLimitDescription))
+	for i := 0; i < EmbedLimitFields+5; i++ {
+		e.AddField("name", strings.Repeat("v", EmbedLimitFieldValue), false)
+	}
+
+	e.Truncate()
+
+	if err := e.Validate(); err != nil {
+		t.Errorf("Validate returned error after Truncate: %v", err)
+	}
+	if n := utf8.RuneCountInString(e.Title); n != EmbedLimitTitle {
+		t.Errorf("title length: got %d, want %d", n, EmbedLimitTitle)
+	}
+	if !strings.HasSuffix(e.Title, "…") {
+		t.Errorf("truncated title does not end with an ellipsis")
+	}
+}
+
+func TestMessageSendSplit(t *testing.T) {
+	line := strings.Repeat("x", 999)
+	m := &MessageSend{
+		Content:   strings.Join([]string{line, line, line}, "\n"),
+		Embeds:    []*MessageEmbed{NewMessageEmbed().SetTitle("embed")},
+		Reference: &MessageReference{MessageID: "1"},
+	}
+
+	parts := m.Split()
+	if len(parts) != 2 {
+		t.Fatalf("Split returned %d messages, want 2", len(parts))
+	}
+	if parts[0].Content != line+"\n"+line || parts[1].Content != line {
+		t.Errorf("content not split at line break")
+	}
+	if parts[0].Reference == nil || parts[1].Reference != nil {
+		t.Errorf("reference should only be kept on the first message")
+	}
+	if len(parts[0].Embeds) != 0 || len(parts[1].Embeds) != 1 {
+		t.Errorf("embeds should only be kept on the last message")
+	}
+}
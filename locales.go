@@ -0,0 +1,156 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the Locale type and helpers to localize
+// application commands and interaction responses.
+
+package discordgo
+
+import (
+	"sort"
+	"strings"
+)
+
+// Locale is a language supported by Discord.
+// https://discord.com/developers/docs/reference#locales
+type Locale string
+
+// All locales supported by Discord.
+const (
+	LocaleIndonesian   Locale = "id"
+	LocaleDanish       Locale = "da"
+	LocaleGerman       Locale = "de"
+	LocaleEnglishGB    Locale = "en-GB"
+	LocaleEnglishUS    Locale = "en-US"
+	LocaleSpanishES    Locale = "es-ES"
+	LocaleSpanishLATAM Locale = "es-419"
+	LocaleFrench       Locale = "fr"
+	LocaleCroatian     Locale = "hr"
+	LocaleItalian      Locale = "it"
+	LocaleLithuanian   Locale = "lt"
+	LocaleHungarian    Locale = "hu"
+	LocaleDutch        Locale = "nl"
+	LocaleNorwegian    Locale = "no"
+	LocalePolish       Locale = "pl"
+	LocalePortugueseBR Locale = "pt-BR"
+	LocaleRomanian     Locale = "ro"
+	LocaleFinnish      Locale = "fi"
+	LocaleSwedish      Locale = "sv-SE"
+	LocaleVietnamese   Locale = "vi"
+	LocaleTurkish      Locale = "tr"
+	LocaleCzech        Locale = "cs"
+	LocaleGreek        Locale = "el"
+	LocaleBulgarian    Locale = "bg"
+	LocaleRussian      Locale = "ru"
+	LocaleUkrainian    Locale = "uk"
+	LocaleHindi        Locale = "hi"
+	LocaleThai         Locale = "th"
+	LocaleChineseCN    Locale = "zh-CN"
+	LocaleJapanese     Locale = "ja"
+	LocaleChineseTW    Locale = "zh-TW"
+	LocaleKorean       Locale = "ko"
+)
+
+// Locales maps all locales supported by Discord to their English names.
+var Locales = map[Locale]string{
+	LocaleIndonesian:   "Indonesian",
+	LocaleDanish:       "Danish",
+	LocaleGerman:       "German",
+	LocaleEnglishGB:    "English, UK",
+	LocaleEnglishUS:    "English, US",
+	LocaleSpanishES:    "Spanish",
+	LocaleSpanishLATAM: "Spanish, LATAM",
+	LocaleFrench:       "French",
+	LocaleCroatian:     "Croatian",
+	LocaleItalian:      "Italian",
+	LocaleLithuanian:   "Lithuanian",
+	LocaleHungarian:    "Hungarian",
+	LocaleDutch:        "Dutch",
+	LocaleNorwegian:    "Norwegian",
+	LocalePolish:       "Polish",
+	LocalePortugueseBR: "Portuguese, Brazilian",
+	LocaleRomanian:     "Romanian, Romania",
+	LocaleFinnish:      "Finnish",
+	LocaleSwedish:      "Swedish",
+	LocaleVietnamese:   "Vietnamese",
+	LocaleTurkish:      "Turkish",
+	LocaleCzech:        "Czech",
+	LocaleGreek:        "Greek",
+	LocaleBulgarian:    "Bulgarian",
+	LocaleRussian:      "Russian",
+	LocaleUkrainian:    "Ukrainian",
+	LocaleHindi:        "Hindi",
+	LocaleThai:         "Thai",
+	LocaleChineseCN:    "Chinese, China",
+	LocaleJapanese:     "Japanese",
+	LocaleChineseTW:    "Chinese, Taiwan",
+	LocaleKorean:       "Korean",
+}
+
+// String returns the English name of the locale, or the locale itself
+// if it is unknown.
+func (l Locale) String() string {
+	if name, ok := Locales[l]; ok {
+		return name
+	}
+	return string(l)
+}
+
+// Language returns the language of the locale without its region,
+// e.g. "es" for both LocaleSpanishES and LocaleSpanishLATAM.
+func (l Locale) Language() string {
+	if i := strings.IndexByte(string(l), '-'); i >= 0 {
+		return string(l[:i])
+	}
+	return string(l)
+}
+
+// Localize returns the best localization of a string for the locale: the
+// localization for the locale itself, otherwise one for another region of
+// its language. ok is false if localizations has neither.
+func (l Locale) Localize(localizations map[Locale]string) (s string, ok bool) {
+	if l == "" {
+		return "", false
+	}
+	if s, ok = localizations[l]; ok {
+		return
+	}
+
+	// Prefer the locales in a stable order, maps are unordered.
+	language := l.Language()
+	for _, other := range sortedLocales(localizations) {
+		if other.Language() == language {
+			return localizations[other], true
+		}
+	}
+	return "", false
+}
+
+// sortedLocales returns the locales of localizations sorted by name.
+func sortedLocales(localizations map[Locale]string) []Locale {
+	locales := make([]Locale, 0, len(localizations))
+	for l := range localizations {
+		locales = append(locales, l)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
+
+// Localize returns the best localization of a string for the user who
+// created the interaction. It tries the locale of the user, then the
+// locale of the guild, and falls back to def.
+// def           : The default, unlocalized string.
+// localizations : The localizations of the string.
+func (i *Interaction) Localize(def string, localizations map[Locale]string) string {
+	if s, ok := i.Locale.Localize(localizations); ok {
+		return s
+	}
+	if s, ok := i.GuildLocale.Localize(localizations); ok {
+		return s
+	}
+	return def
+}
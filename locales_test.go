@@ -0,0 +1,41 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInteractionLocalize(t *testing.T) {
+	localizations := map[Locale]string{
+		LocaleGerman:       "Hallo",
+		LocaleSpanishLATAM: "Hola",
+		LocaleFrench:       "Bonjour",
+	}
+
+	tests := []struct {
+		locale, guildLocale Locale
+		want                string
+	}{
+		{LocaleGerman, LocaleFrench, "Hallo"},
+		{LocaleSpanishES, "", "Hola"},
+		{LocaleJapanese, LocaleFrench, "Bonjour"},
+		{LocaleJapanese, "", "Hello"},
+	}
+	for _, tt := range tests {
+		i := &Interaction{Locale: tt.locale, GuildLocale: tt.guildLocale}
+		if got := i.Localize("Hello", localizations); got != tt.want {
+			t.Errorf("%s, %s: got %q, want %q", tt.locale, tt.guildLocale, got, tt.want)
+		}
+	}
+}
+
+func TestInteractionLocaleUnmarshal(t *testing.T) {
+	var i Interaction
+	err := json.Unmarshal([]byte(`{"type": 2, "data": {"name": "ping"}, "locale": "pt-BR", "guild_locale": "en-US"}`), &i)
+	if err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if i.Locale != LocalePortugueseBR || i.GuildLocale != LocaleEnglishUS {
+		t.Errorf("got locale %q and guild locale %q", i.Locale, i.GuildLocale)
+	}
+}
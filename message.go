@@ -186,6 +186,38 @@ type File struct {
 	Name        string
 	ContentType string
 	Reader      io.Reader
+
+	// Description is the alt text of the uploaded attachment.
+	Description string
+
+	// If true, the attachment is uploaded as a spoiler.
+	Spoiler bool
+
+	// Open, if set, is used instead of Reader to get the contents of the
+	// file and is called again whenever the upload has to be retried.
+	// Without it the upload is streamed from Reader when it is an
+	// io.Seeker, and otherwise the contents are kept in memory for retries.
+	Open func() (io.ReadCloser, error)
+
+	// Progress, if set, is called during the upload with the number of
+	// bytes of the file sent so far. It starts over when a request is
+	// retried.
+	Progress func(sent int64)
+}
+
+// filename returns the name the file is uploaded with.
+func (f *File) filename() string {
+	if f.Spoiler && !strings.HasPrefix(f.Name, "SPOILER_") {
+		return "SPOILER_" + f.Name
+	}
+	return f.Name
+}
+
+// AttachmentURL returns the attachment:// URL which can be used to
+// reference the uploaded file from an embed of the same message, e.g. as
+// the image URL.
+func (f *File) AttachmentURL() string {
+	return "attachment://" + f.filename()
 }
 
 // MessageSend stores all parameters you can send with ChannelMessageSendComplex.
@@ -198,6 +230,10 @@ type MessageSend struct {
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
 	Reference       *MessageReference       `json:"message_reference,omitempty"`
 
+	// Attachments describes the uploaded Files. Entries for Files which
+	// are not listed are added automatically, see File.
+	Attachments []*MessageAttachment `json:"attachments,omitempty"`
+
 	// TODO: Remove this when compatibility is not required.
 	File *File `json:"-"`
 
@@ -212,6 +248,13 @@ type MessageEdit struct {
 	Components      []MessageComponent      `json:"components"`
 	Embeds          []*MessageEmbed         `json:"embeds,omitempty"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+	Files           []*File                 `json:"-"`
+
+	// Attachments lists the existing attachments to keep, all others are
+	// removed from the message. A pointer to an empty slice removes every
+	// attachment, nil leaves them untouched. It is required to add Files
+	// with a Description.
+	Attachments *[]*MessageAttachment `json:"attachments,omitempty"`
 
 	ID      string
 	Channel string
@@ -250,6 +293,13 @@ func (m *MessageEdit) SetEmbeds(embeds []*MessageEmbed) *MessageEdit {
 	return m
 }
 
+// SetAttachments is a convenience function for setting the attachments
+// to keep, so you can chain commands.
+func (m *MessageEdit) SetAttachments(attachments []*MessageAttachment) *MessageEdit {
+	m.Attachments = &attachments
+	return m
+}
+
 // AllowedMentionType describes the types of mentions used
 // in the MessageAllowedMentions type.
 type AllowedMentionType string
@@ -288,14 +338,16 @@ type MessageAllowedMentions struct {
 
 // A MessageAttachment stores data for message attachments.
 type MessageAttachment struct {
-	ID        string `json:"id"`
-	URL       string `json:"url"`
-	ProxyURL  string `json:"proxy_url"`
-	Filename  string `json:"filename"`
-	Width     int    `json:"width"`
-	Height    int    `json:"height"`
-	Size      int    `json:"size"`
-	Ephemeral bool   `json:"ephemeral"`
+	ID          string `json:"id"`
+	URL         string `json:"url,omitempty"`
+	ProxyURL    string `json:"proxy_url,omitempty"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	Ephemeral   bool   `json:"ephemeral,omitempty"`
 }
 
 // MessageEmbedFooter is a part of a MessageEmbed struct.
@@ -85,7 +85,7 @@ func (s *Session) Applications() (st []*Application, err error) {
 // ApplicationCreate creates a new Application
 //    name : Name of Application / Bot
 //    uris : Redirect URIs (Not required)
-func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error) {
+func (s *Session) ApplicationCreate(ap *Application, options ...RequestOption) (st *Application, err error) {
 
 	data := struct {
 		Name         string    `json:"name"`
@@ -93,7 +93,7 @@ func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error
 		RedirectURIs *[]string `json:"redirect_uris,omitempty"`
 	}{ap.Name, ap.Description, ap.RedirectURIs}
 
-	body, err := s.RequestWithBucketID("POST", EndpointOAuth2Applications, data, EndpointOAuth2Applications)
+	body, err := s.RequestWithBucketID("POST", EndpointOAuth2Applications, data, EndpointOAuth2Applications, options...)
 	if err != nil {
 		return
 	}
@@ -104,7 +104,7 @@ func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error
 
 // ApplicationUpdate updates an existing Application
 //   var : desc
-func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Application, err error) {
+func (s *Session) ApplicationUpdate(appID string, ap *Application, options ...RequestOption) (st *Application, err error) {
 
 	data := struct {
 		Name         string    `json:"name"`
@@ -112,7 +112,7 @@ func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Applicat
 		RedirectURIs *[]string `json:"redirect_uris,omitempty"`
 	}{ap.Name, ap.Description, ap.RedirectURIs}
 
-	body, err := s.RequestWithBucketID("PUT", EndpointOAuth2Application(appID), data, EndpointOAuth2Application(""))
+	body, err := s.RequestWithBucketID("PUT", EndpointOAuth2Application(appID), data, EndpointOAuth2Application(""), options...)
 	if err != nil {
 		return
 	}
@@ -123,9 +123,9 @@ func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Applicat
 
 // ApplicationDelete deletes an existing Application
 //   appID : The ID of an Application
-func (s *Session) ApplicationDelete(appID string) (err error) {
+func (s *Session) ApplicationDelete(appID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointOAuth2Application(appID), nil, EndpointOAuth2Application(""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointOAuth2Application(appID), nil, EndpointOAuth2Application(""), options...)
 	if err != nil {
 		return
 	}
@@ -161,9 +161,9 @@ func (s *Session) ApplicationAssets(appID string) (ass []*Asset, err error) {
 //   appID : The ID of an Application
 //
 // NOTE: func name may change, if I can think up something better.
-func (s *Session) ApplicationBotCreate(appID string) (st *User, err error) {
+func (s *Session) ApplicationBotCreate(appID string, options ...RequestOption) (st *User, err error) {
 
-	body, err := s.RequestWithBucketID("POST", EndpointOAuth2ApplicationsBot(appID), nil, EndpointOAuth2ApplicationsBot(""))
+	body, err := s.RequestWithBucketID("POST", EndpointOAuth2ApplicationsBot(appID), nil, EndpointOAuth2ApplicationsBot(""), options...)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,354 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains iterators which page through the list endpoints of
+// the Discord REST API.
+
+package discordgo
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrPageDirectionUnsupported is returned by the Err method of an iterator
+// if the endpoint can not be paged in the requested direction.
+var ErrPageDirectionUnsupported = errors.New("the endpoint can not be paged in this direction")
+
+// PageDirection is the direction in which an iterator walks a list.
+type PageDirection int
+
+// Valid PageDirection values
+const (
+	// PageDefault walks messages and audit log entries backwards and all
+	// other lists forwards.
+	PageDefault PageDirection = iota
+
+	// PageBackward walks from the newest to the oldest item.
+	PageBackward
+
+	// PageForward walks from the oldest to the newest item.
+	PageForward
+)
+
+// PageParams are the parameters of the iterators over paginated lists.
+// Items are identified by their snowflake ID, use SnowflakeFromTime to
+// start or stop at a point in time.
+type PageParams struct {
+	Direction PageDirection
+
+	// The iterator starts after the item with this ID when walking
+	// forwards and before it when walking backwards.
+	// If empty, it starts at the oldest or newest item.
+	StartID string
+
+	// The iterator stops before reaching the item with this ID.
+	// If empty, it stops at the end of the list.
+	StopID string
+
+	// The maximum number of items returned by the iterator, 0 for all.
+	Limit int
+
+	// The number of items requested at a time, 0 for the maximum of the
+	// endpoint.
+	PageSize int
+}
+
+// paginator is the logic shared by the iterators. fetch requests a page of
+// at most limit items before or after the given ID and returns its length,
+// id returns the ID of the item at index i of the last page.
+type paginator struct {
+	params   PageParams
+	backward bool
+	fetch    func(beforeID, afterID string, limit int) (int, error)
+	id       func(i int) string
+
+	cursor string
+	order  []int
+	pos    int
+	count  int
+	done   bool
+	err    error
+}
+
+// newPaginator returns a paginator for an endpoint returning at most
+// maxPageSize items per request.
+// canBackward is false for endpoints which can only be paged forwards.
+func newPaginator(params *PageParams, maxPageSize int, backwardByDefault, canBackward bool, fetch func(beforeID, afterID string, limit int) (int, error), id func(i int) string) paginator {
+	p := paginator{fetch: fetch, id: id}
+	if params != nil {
+		p.params = *params
+	}
+	if p.params.PageSize <= 0 || p.params.PageSize > maxPageSize {
+		p.params.PageSize = maxPageSize
+	}
+
+	switch p.params.Direction {
+	case PageDefault:
+		p.backward = backwardByDefault
+	case PageBackward:
+		p.backward = true
+	}
+	if p.backward && !canBackward {
+		p.err = ErrPageDirectionUnsupported
+	}
+
+	p.cursor = p.params.StartID
+	if p.cursor == "" && !p.backward {
+		p.cursor = "0"
+	}
+	return p
+}
+
+// Next advances the iterator to the next item, requesting the next page
+// if needed. It returns false when there are no more items or an error
+// occurred, which is returned by Err.
+func (p *paginator) Next() bool {
+	if p.err != nil || (p.params.Limit > 0 && p.count >= p.params.Limit) {
+		return false
+	}
+	if p.pos+1 < len(p.order) {
+		p.pos++
+		p.count++
+		return true
+	}
+	if p.done {
+		return false
+	}
+
+	limit := p.params.PageSize
+	if p.params.Limit > 0 && p.params.Limit-p.count < limit {
+		limit = p.params.Limit - p.count
+	}
+
+	var n int
+	if p.backward {
+		n, p.err = p.fetch(p.cursor, "", limit)
+	} else {
+		n, p.err = p.fetch("", p.cursor, limit)
+	}
+	if p.err != nil {
+		return false
+	}
+	if n < limit {
+		p.done = true
+	}
+
+	// Pages are not sorted the same way by every endpoint, so the items
+	// are put in the order of the walk.
+	p.order = p.order[:0]
+	for i := 0; i < n; i++ {
+		id := p.id(i)
+		if p.cursor == "" || p.further(id, p.cursor) {
+			p.cursor = id
+		}
+		if p.params.StopID != "" && !p.further(p.params.StopID, id) {
+			p.done = true
+			continue
+		}
+		p.order = append(p.order, i)
+	}
+	sort.Slice(p.order, func(a, b int) bool {
+		return p.further(p.id(p.order[b]), p.id(p.order[a]))
+	})
+
+	p.pos = 0
+	if len(p.order) == 0 {
+		p.done = true
+		return false
+	}
+	p.count++
+	return true
+}
+
+// further reports whether the item with ID a comes after the item with
+// ID b in the walk.
+func (p *paginator) further(a, b string) bool {
+	if p.backward {
+		return CompareSnowflakes(a, b) < 0
+	}
+	return CompareSnowflakes(b, a) < 0
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (p *paginator) Err() error {
+	return p.err
+}
+
+// index returns the index of the current item in the last page.
+func (p *paginator) index() int {
+	return p.order[p.pos]
+}
+
+// MessageIterator walks the messages of a channel.
+//
+//	it := s.ChannelMessagesIterator(channelID, &PageParams{
+//		Direction: PageForward,
+//		StartID:   SnowflakeFromTime(since),
+//	})
+//	for it.Next() {
+//		m := it.Message()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type MessageIterator struct {
+	paginator
+	page []*Message
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *Message {
+	return it.page[it.index()]
+}
+
+// ChannelMessagesIterator returns an iterator over the messages of a
+// channel, which walks backwards by default.
+// channelID : The ID of a Channel.
+// params    : The parameters of the iterator, may be nil.
+func (s *Session) ChannelMessagesIterator(channelID string, params *PageParams) *MessageIterator {
+	it := &MessageIterator{}
+	it.paginator = newPaginator(params, 100, true, true, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.ChannelMessages(channelID, limit, beforeID, afterID, "")
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].ID
+	})
+	return it
+}
+
+// MemberIterator walks the members of a guild.
+type MemberIterator struct {
+	paginator
+	page []*Member
+}
+
+// Member returns the current member.
+func (it *MemberIterator) Member() *Member {
+	return it.page[it.index()]
+}
+
+// GuildMembersIterator returns an iterator over the members of a guild,
+// ordered by user ID. Members can only be walked forwards.
+// guildID : The ID of a Guild.
+// params  : The parameters of the iterator, may be nil.
+func (s *Session) GuildMembersIterator(guildID string, params *PageParams) *MemberIterator {
+	it := &MemberIterator{}
+	it.paginator = newPaginator(params, 1000, false, false, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.GuildMembers(guildID, afterID, limit)
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].User.ID
+	})
+	return it
+}
+
+// UserGuildIterator walks the guilds of the current user.
+type UserGuildIterator struct {
+	paginator
+	page []*UserGuild
+}
+
+// Guild returns the current guild.
+func (it *UserGuildIterator) Guild() *UserGuild {
+	return it.page[it.index()]
+}
+
+// UserGuildsIterator returns an iterator over the guilds of the current
+// user, which walks forwards by default.
+// params : The parameters of the iterator, may be nil.
+func (s *Session) UserGuildsIterator(params *PageParams) *UserGuildIterator {
+	it := &UserGuildIterator{}
+	it.paginator = newPaginator(params, 200, false, true, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.UserGuilds(limit, beforeID, afterID)
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].ID
+	})
+	return it
+}
+
+// UserIterator walks a list of users.
+type UserIterator struct {
+	paginator
+	page []*User
+}
+
+// User returns the current user.
+func (it *UserIterator) User() *User {
+	return it.page[it.index()]
+}
+
+// MessageReactionsIterator returns an iterator over the users who reacted
+// to a message with an emoji. Reactions can only be walked forwards.
+// channelID : The channel ID.
+// messageID : The message ID.
+// emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
+// params    : The parameters of the iterator, may be nil.
+func (s *Session) MessageReactionsIterator(channelID, messageID, emojiID string, params *PageParams) *UserIterator {
+	it := &UserIterator{}
+	it.paginator = newPaginator(params, 100, false, false, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.MessageReactions(channelID, messageID, emojiID, limit, beforeID, afterID)
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].ID
+	})
+	return it
+}
+
+// GuildBanIterator walks the bans of a guild.
+type GuildBanIterator struct {
+	paginator
+	page []*GuildBan
+}
+
+// Ban returns the current ban.
+func (it *GuildBanIterator) Ban() *GuildBan {
+	return it.page[it.index()]
+}
+
+// GuildBansIterator returns an iterator over the bans of a guild, ordered
+// by user ID, which walks forwards by default.
+// guildID : The ID of a Guild.
+// params  : The parameters of the iterator, may be nil.
+func (s *Session) GuildBansIterator(guildID string, params *PageParams) *GuildBanIterator {
+	it := &GuildBanIterator{}
+	it.paginator = newPaginator(params, 1000, false, true, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.guildBans(guildID, limit, beforeID, afterID)
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].User.ID
+	})
+	return it
+}
+
+// GuildScheduledEventUserIterator walks the users subscribed to a
+// scheduled event.
+type GuildScheduledEventUserIterator struct {
+	paginator
+	page []*GuildScheduledEventUser
+}
+
+// User returns the current user.
+func (it *GuildScheduledEventUserIterator) User() *GuildScheduledEventUser {
+	return it.page[it.index()]
+}
+
+// GuildScheduledEventUsersIterator returns an iterator over the users
+// subscribed to a scheduled event, ordered by user ID, which walks
+// forwards by default.
+// guildID   : The ID of a Guild
+// eventID   : The ID of the event
+// params    : The parameters of the iterator, may be nil.
+func (s *Session) GuildScheduledEventUsersIterator(guildID, eventID string, params *PageParams) *GuildScheduledEventUserIterator {
+	it := &GuildScheduledEventUserIterator{}
+	it.paginator = newPaginator(params, 100, false, true, func(beforeID, afterID string, limit int) (n int, err error) {
+		it.page, err = s.guildScheduledEventUsers(guildID, eventID, limit, beforeID, afterID)
+		return len(it.page), err
+	}, func(i int) string {
+		return it.page[i].User.ID
+	})
+	return it
+}
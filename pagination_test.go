@@ -0,0 +1,95 @@
+package discordgo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testMessagesHandler serves the messages with IDs 1 to 10 like Discord,
+// newest first for both before and after.
+func testMessagesHandler(requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*requests = append(*requests, q.Encode())
+
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var ids []int
+		if after := q.Get("after"); after != "" {
+			a, _ := strconv.Atoi(after)
+			for id := a + 1; id <= 10 && len(ids) < limit; id++ {
+				ids = append([]int{id}, ids...)
+			}
+		} else {
+			b := 11
+			if before := q.Get("before"); before != "" {
+				b, _ = strconv.Atoi(before)
+			}
+			for id := b - 1; id >= 1 && len(ids) < limit; id-- {
+				ids = append(ids, id)
+			}
+		}
+
+		messages := make([]string, len(ids))
+		for i, id := range ids {
+			messages[i] = fmt.Sprintf(`{"id": "%d"}`, id)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(messages, ","))
+	}
+}
+
+func TestChannelMessagesIterator(t *testing.T) {
+	var requests []string
+	s, closeServer := newTestSession(testMessagesHandler(&requests))
+	defer closeServer()
+
+	tests := []struct {
+		params *PageParams
+		want   []string
+	}{
+		{&PageParams{PageSize: 4}, []string{"10", "9", "8", "7", "6", "5", "4", "3", "2", "1"}},
+		{&PageParams{Direction: PageForward, StartID: "6", PageSize: 3}, []string{"7", "8", "9", "10"}},
+		{&PageParams{StartID: "9", StopID: "4", PageSize: 2}, []string{"8", "7", "6", "5"}},
+		{&PageParams{Direction: PageForward, Limit: 5, PageSize: 3}, []string{"1", "2", "3", "4", "5"}},
+	}
+	for _, tt := range tests {
+		it := s.ChannelMessagesIterator("channel", tt.params)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.Message().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Errorf("%+v: iterator returned error: %v", tt.params, err)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("%+v: got messages %v, want %v", tt.params, ids, tt.want)
+		}
+	}
+
+	// The last request of a walk with a limit only asks for the rest.
+	if last := requests[len(requests)-1]; last != "after=3&limit=2" {
+		t.Errorf("last request: got %q, want %q", last, "after=3&limit=2")
+	}
+}
+
+func TestGuildMembersIteratorDirection(t *testing.T) {
+	it := (&Session{}).GuildMembersIterator("guild", &PageParams{Direction: PageBackward})
+	if it.Next() || it.Err() != ErrPageDirectionUnsupported {
+		t.Errorf("backward iterator over members: got error %v, want %v", it.Err(), ErrPageDirectionUnsupported)
+	}
+}
+
+func TestSnowflakeFromTime(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	ts, err := SnowflakeTimestamp(SnowflakeFromTime(now))
+	if err != nil {
+		t.Fatalf("SnowflakeTimestamp returned error: %v", err)
+	}
+	if !ts.Equal(now) {
+		t.Errorf("got time %v, want %v", ts, now)
+	}
+}
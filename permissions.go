@@ -0,0 +1,550 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the Permissions type and a calculator for the
+// effective permissions of a member.
+
+package discordgo
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Permissions is a set of permissions, a combination of the Permission*
+// constants. It is marshaled to JSON as a string, like Discord does.
+// https://discord.com/developers/docs/topics/permissions
+type Permissions int64
+
+// permissionNames are the names of the permissions, used by String.
+var permissionNames = []struct {
+	perm Permissions
+	name string
+}{
+	{PermissionCreateInstantInvite, "CreateInstantInvite"},
+	{PermissionKickMembers, "KickMembers"},
+	{PermissionBanMembers, "BanMembers"},
+	{PermissionAdministrator, "Administrator"},
+	{PermissionManageChannels, "ManageChannels"},
+	{PermissionManageServer, "ManageServer"},
+	{PermissionAddReactions, "AddReactions"},
+	{PermissionViewAuditLogs, "ViewAuditLogs"},
+	{PermissionVoicePrioritySpeaker, "VoicePrioritySpeaker"},
+	{PermissionVoiceStreamVideo, "VoiceStreamVideo"},
+	{PermissionViewChannel, "ViewChannel"},
+	{PermissionSendMessages, "SendMessages"},
+	{PermissionSendTTSMessages, "SendTTSMessages"},
+	{PermissionManageMessages, "ManageMessages"},
+	{PermissionEmbedLinks, "EmbedLinks"},
+	{PermissionAttachFiles, "AttachFiles"},
+	{PermissionReadMessageHistory, "ReadMessageHistory"},
+	{PermissionMentionEveryone, "MentionEveryone"},
+	{PermissionUseExternalEmojis, "UseExternalEmojis"},
+	{PermissionViewGuildInsights, "ViewGuildInsights"},
+	{PermissionVoiceConnect, "VoiceConnect"},
+	{PermissionVoiceSpeak, "VoiceSpeak"},
+	{PermissionVoiceMuteMembers, "VoiceMuteMembers"},
+	{PermissionVoiceDeafenMembers, "VoiceDeafenMembers"},
+	{PermissionVoiceMoveMembers, "VoiceMoveMembers"},
+	{PermissionVoiceUseVAD, "VoiceUseVAD"},
+	{PermissionChangeNickname, "ChangeNickname"},
+	{PermissionManageNicknames, "ManageNicknames"},
+	{PermissionManageRoles, "ManageRoles"},
+	{PermissionManageWebhooks, "ManageWebhooks"},
+	{PermissionManageEmojis, "ManageEmojis"},
+	{PermissionUseSlashCommands, "UseSlashCommands"},
+	{PermissionVoiceRequestToSpeak, "VoiceRequestToSpeak"},
+	{PermissionManageEvents, "ManageEvents"},
+	{PermissionManageThreads, "ManageThreads"},
+	{PermissionCreatePublicThreads, "CreatePublicThreads"},
+	{PermissionCreatePrivateThreads, "CreatePrivateThreads"},
+	{PermissionUseExternalStickers, "UseExternalStickers"},
+	{PermissionSendMessagesInThreads, "SendMessagesInThreads"},
+	{PermissionUseEmbeddedActivities, "UseEmbeddedActivities"},
+	{PermissionModerateMembers, "ModerateMembers"},
+	{PermissionViewCreatorMonetizationAnalytics, "ViewCreatorMonetizationAnalytics"},
+	{PermissionUseSoundboard, "UseSoundboard"},
+	{PermissionCreateGuildExpressions, "CreateGuildExpressions"},
+	{PermissionCreateEvents, "CreateEvents"},
+	{PermissionUseExternalSounds, "UseExternalSounds"},
+	{PermissionSendVoiceMessages, "SendVoiceMessages"},
+	{PermissionSendPolls, "SendPolls"},
+	{PermissionUseExternalApps, "UseExternalApps"},
+}
+
+// Has reports whether p contains all of perms.
+func (p Permissions) Has(perms Permissions) bool {
+	return p&perms == perms
+}
+
+// Add returns p with perms added.
+func (p Permissions) Add(perms Permissions) Permissions {
+	return p | perms
+}
+
+// Remove returns p with perms removed.
+func (p Permissions) Remove(perms Permissions) Permissions {
+	return p &^ perms
+}
+
+// String returns the names of the permissions separated by |,
+// e.g. "ViewChannel|SendMessages". Unknown bits are shown in hex.
+func (p Permissions) String() string {
+	if p == 0 {
+		return "None"
+	}
+
+	var names []string
+	rest := p
+	for _, n := range permissionNames {
+		if p.Has(n.perm) {
+			names = append(names, n.name)
+			rest &^= n.perm
+		}
+	}
+	if rest != 0 {
+		names = append(names, "0x"+strconv.FormatInt(int64(rest), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalJSON is a helper function to marshal the Permissions as a string.
+func (p Permissions) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatInt(int64(p), 10) + `"`), nil
+}
+
+// UnmarshalJSON is a helper function to unmarshal Permissions from a
+// string or number.
+func (p *Permissions) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	i, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*p = Permissions(i)
+	return nil
+}
+
+// PermissionSource is the step of the permission calculation which
+// allowed or denied a permission.
+type PermissionSource int
+
+// Valid PermissionSource values
+const (
+	// The permission was never allowed.
+	PermissionSourceNone PermissionSource = iota
+
+	// The permission was allowed by the role RoleID. The ID of the
+	// @everyone role is the ID of the guild.
+	PermissionSourceRole
+
+	// The permission was allowed because the member owns the guild.
+	PermissionSourceOwner
+
+	// The permission was allowed by the Administrator permission.
+	PermissionSourceAdministrator
+
+	// The permission was allowed or denied by the channel overwrite
+	// Overwrite.
+	PermissionSourceOverwrite
+
+	// The permission was denied because the member is timed out.
+	PermissionSourceTimeout
+
+	// The permission was allowed or denied because of the permission
+	// DependsOn, e.g. SendMessages is denied without ViewChannel.
+	PermissionSourceImplicit
+)
+
+// A PermissionReason explains why a permission was allowed or denied.
+type PermissionReason struct {
+	Allowed bool
+	Source  PermissionSource
+
+	// The role of PermissionSourceRole.
+	RoleID string
+
+	// The overwrite of PermissionSourceOverwrite.
+	Overwrite *PermissionOverwrite
+
+	// The permission of PermissionSourceImplicit.
+	DependsOn Permissions
+}
+
+// A PermissionExplanation holds the permissions computed by a
+// PermissionCalculator and why each of them was allowed or denied.
+type PermissionExplanation struct {
+	Permissions Permissions
+
+	// The reasons of every single permission which was allowed or
+	// denied at some point.
+	Reasons map[Permissions]*PermissionReason
+}
+
+// Reason returns why the single permission perm was allowed or denied.
+func (e *PermissionExplanation) Reason(perm Permissions) *PermissionReason {
+	if r, ok := e.Reasons[perm]; ok {
+		return r
+	}
+	return &PermissionReason{Source: PermissionSourceNone}
+}
+
+// allow allows perms, recording the reason for every permission which
+// was not allowed yet.
+func (e *PermissionExplanation) allow(perms Permissions, reason PermissionReason) {
+	reason.Allowed = true
+	e.set(perms&^e.Permissions, &reason)
+	e.Permissions |= perms
+}
+
+// deny denies perms, recording the reason for every permission which
+// was allowed.
+func (e *PermissionExplanation) deny(perms Permissions, reason PermissionReason) {
+	reason.Allowed = false
+	e.set(perms&e.Permissions, &reason)
+	e.Permissions &^= perms
+}
+
+func (e *PermissionExplanation) set(perms Permissions, reason *PermissionReason) {
+	for bit := Permissions(1); perms != 0; bit <<= 1 {
+		if perms&bit != 0 {
+			e.Reasons[bit] = reason
+			perms &^= bit
+		}
+	}
+}
+
+// A PermissionCalculator computes the effective permissions of a member
+// in a guild or channel, as described in
+// https://discord.com/developers/docs/topics/permissions#permission-overwrites
+// It only uses the given structs and does not need State.
+type PermissionCalculator struct {
+	// The guild, with its ID, OwnerID and Roles.
+	Guild *Guild
+
+	// The member, with its User, Roles and CommunicationDisabledUntil.
+	// It must not be nil.
+	Member *Member
+
+	// The channel, or nil for the permissions in the guild.
+	Channel *Channel
+
+	// The parent channel of Channel if it is a thread, which holds the
+	// overwrites of the thread. If nil, it is looked up in Guild.Channels.
+	Parent *Channel
+}
+
+// Permissions returns the effective permissions of the member.
+func (c *PermissionCalculator) Permissions() Permissions {
+	return c.Explain().Permissions
+}
+
+// Explain returns the effective permissions of the member and which
+// role, overwrite or rule allowed or denied each of them.
+func (c *PermissionCalculator) Explain() *PermissionExplanation {
+	e := &PermissionExplanation{Reasons: make(map[Permissions]*PermissionReason)}
+
+	var userID string
+	if c.Member.User != nil {
+		userID = c.Member.User.ID
+	}
+
+	if userID != "" && userID == c.Guild.OwnerID {
+		e.allow(PermissionAll, PermissionReason{Source: PermissionSourceOwner})
+		return e
+	}
+
+	// The @everyone role first, then the roles of the member.
+	for _, role := range c.Guild.Roles {
+		if role.ID == c.Guild.ID {
+			e.allow(Permissions(role.Permissions), PermissionReason{Source: PermissionSourceRole, RoleID: role.ID})
+			break
+		}
+	}
+	for _, role := range c.Guild.Roles {
+		if role.ID != c.Guild.ID && c.hasRole(role.ID) {
+			e.allow(Permissions(role.Permissions), PermissionReason{Source: PermissionSourceRole, RoleID: role.ID})
+		}
+	}
+
+	if e.Permissions.Has(PermissionAdministrator) {
+		e.allow(PermissionAll, PermissionReason{Source: PermissionSourceAdministrator})
+		return e
+	}
+
+	channel := c.overwriteChannel()
+	if channel != nil {
+		c.applyOverwrites(e, channel.PermissionOverwrites, userID)
+	}
+
+	if c.Member.TimedOut() {
+		e.deny(PermissionAll&^(PermissionViewChannel|PermissionReadMessageHistory), PermissionReason{Source: PermissionSourceTimeout})
+	}
+
+	if c.Channel != nil {
+		c.applyImplicit(e)
+	}
+
+	return e
+}
+
+func (c *PermissionCalculator) hasRole(roleID string) bool {
+	for _, id := range c.Member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// overwriteChannel returns the channel which holds the overwrites of
+// Channel, which is its parent for threads.
+func (c *PermissionCalculator) overwriteChannel() *Channel {
+	if c.Channel == nil || !c.Channel.IsThread() {
+		return c.Channel
+	}
+	if c.Parent != nil {
+		return c.Parent
+	}
+	for _, ch := range c.Guild.Channels {
+		if ch.ID == c.Channel.ParentID {
+			return ch
+		}
+	}
+	return c.Channel
+}
+
+// applyOverwrites applies the @everyone overwrite, then all role
+// overwrites of the member at once, then the member overwrite.
+func (c *PermissionCalculator) applyOverwrites(e *PermissionExplanation, overwrites []*PermissionOverwrite, userID string) {
+	for _, o := range overwrites {
+		if o.ID == c.Guild.ID {
+			e.deny(Permissions(o.Deny), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+			e.allow(Permissions(o.Allow), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+			break
+		}
+	}
+
+	var roleOverwrites []*PermissionOverwrite
+	for _, o := range overwrites {
+		if o.Type == PermissionOverwriteTypeRole && o.ID != c.Guild.ID && c.hasRole(o.ID) {
+			roleOverwrites = append(roleOverwrites, o)
+		}
+	}
+	for _, o := range roleOverwrites {
+		e.deny(Permissions(o.Deny), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+	}
+	for _, o := range roleOverwrites {
+		e.allow(Permissions(o.Allow), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+	}
+
+	for _, o := range overwrites {
+		if o.Type == PermissionOverwriteTypeMember && o.ID == userID {
+			e.deny(Permissions(o.Deny), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+			e.allow(Permissions(o.Allow), PermissionReason{Source: PermissionSourceOverwrite, Overwrite: o})
+			break
+		}
+	}
+}
+
+// applyImplicit applies the permissions which depend on others in a
+// channel.
+func (c *PermissionCalculator) applyImplicit(e *PermissionExplanation) {
+	// In threads, SendMessagesInThreads takes the place of SendMessages.
+	if c.Channel.IsThread() {
+		reason := PermissionReason{Source: PermissionSourceImplicit, DependsOn: PermissionSendMessagesInThreads}
+		if e.Permissions.Has(PermissionSendMessagesInThreads) {
+			e.allow(PermissionSendMessages, reason)
+		} else {
+			e.deny(PermissionSendMessages, reason)
+		}
+	}
+
+	if !e.Permissions.Has(PermissionViewChannel) {
+		e.deny(PermissionAll, PermissionReason{Source: PermissionSourceImplicit, DependsOn: PermissionViewChannel})
+		return
+	}
+
+	if !e.Permissions.Has(PermissionSendMessages) {
+		e.deny(PermissionSendTTSMessages|PermissionMentionEveryone|PermissionEmbedLinks|PermissionAttachFiles,
+			PermissionReason{Source: PermissionSourceImplicit, DependsOn: PermissionSendMessages})
+	}
+
+	if c.Channel.Type == ChannelTypeGuildVoice || c.Channel.Type == ChannelTypeGuildStageVoice {
+		if !e.Permissions.Has(PermissionVoiceConnect) {
+			e.deny(PermissionAllVoice&^PermissionViewChannel, PermissionReason{Source: PermissionSourceImplicit, DependsOn: PermissionVoiceConnect})
+		}
+	}
+}
+
+// MissingPermissionsError is returned by the REST methods of a Session
+// with PermissionChecks enabled when the bot lacks permissions required
+// by the request, which is then not sent.
+type MissingPermissionsError struct {
+	GuildID string
+
+	// The channel the permissions were checked in, empty for guild
+	// permissions.
+	ChannelID string
+
+	// The required permissions the bot does not have.
+	Missing Permissions
+}
+
+// Error returns a string representation of the MissingPermissionsError.
+func (e *MissingPermissionsError) Error() string {
+	if e.ChannelID != "" {
+		return fmt.Sprintf("missing permissions %s in channel %s", e.Missing, e.ChannelID)
+	}
+	return fmt.Sprintf("missing permissions %s in guild %s", e.Missing, e.GuildID)
+}
+
+// checkGuildPermissions checks that the bot has the required permissions
+// in the guild, if PermissionChecks is enabled.
+func (s *Session) checkGuildPermissions(guildID string, required Permissions) error {
+	if !s.PermissionChecks || s.State == nil || required == 0 {
+		return nil
+	}
+	return s.checkPermissions(guildID, nil, required)
+}
+
+// checkChannelPermissions checks that the bot has the required
+// permissions in the channel, if PermissionChecks is enabled.
+func (s *Session) checkChannelPermissions(channelID string, required Permissions) error {
+	if !s.PermissionChecks || s.State == nil || required == 0 {
+		return nil
+	}
+
+	channel, err := s.State.Channel(channelID)
+	if err != nil || channel.GuildID == "" {
+		return nil
+	}
+	return s.checkPermissions(channel.GuildID, channel, required)
+}
+
+// checkPermissions computes the permissions of the bot from the state.
+// If the state lacks anything needed, the check passes and Discord
+// decides.
+func (s *Session) checkPermissions(guildID string, channel *Channel, required Permissions) error {
+	if s.State.User == nil {
+		return nil
+	}
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return nil
+	}
+	member, err := s.State.Member(guildID, s.State.User.ID)
+	if err != nil {
+		return nil
+	}
+
+	var parent *Channel
+	if channel != nil && channel.IsThread() {
+		parent, err = s.State.Channel(channel.ParentID)
+		if err != nil {
+			return nil
+		}
+	}
+
+	s.State.RLock()
+	m := *member
+	m.User = s.State.User
+	c := &PermissionCalculator{Guild: guild, Member: &m, Channel: channel, Parent: parent}
+	missing := required &^ c.Permissions()
+	s.State.RUnlock()
+
+	if missing == 0 {
+		return nil
+	}
+
+	e := &MissingPermissionsError{GuildID: guildID, Missing: missing}
+	if channel != nil {
+		e.ChannelID = channel.ID
+	}
+	return e
+}
+
+// requiredPermissions returns the permissions needed to send the message.
+func (m *MessageSend) requiredPermissions() Permissions {
+	p := Permissions(PermissionViewChannel | PermissionSendMessages)
+	if m.TTS {
+		p |= PermissionSendTTSMessages
+	}
+	if m.Embed != nil || len(m.Embeds) > 0 {
+		p |= PermissionEmbedLinks
+	}
+	if m.File != nil || len(m.Files) > 0 {
+		p |= PermissionAttachFiles
+	}
+	if m.Reference != nil {
+		p |= PermissionReadMessageHistory
+	}
+	return p
+}
+
+// requiredPermissions returns the permissions needed to edit the message.
+func (m *MessageEdit) requiredPermissions() Permissions {
+	var p Permissions
+	if m.Embed != nil || len(m.Embeds) > 0 {
+		p |= PermissionEmbedLinks
+	}
+	if len(m.Files) > 0 {
+		p |= PermissionAttachFiles
+	}
+	return p
+}
+
+// requiredPermissions returns the permissions needed to edit the member.
+func (p *GuildMemberParams) requiredPermissions(userID string) Permissions {
+	var perms Permissions
+	if p == nil {
+		return perms
+	}
+	if p.Nick != nil {
+		perms |= nicknamePermission(userID)
+	}
+	if p.Roles != nil {
+		perms |= PermissionManageRoles
+	}
+	if p.ChannelID != nil {
+		perms |= PermissionVoiceMoveMembers
+	}
+	if p.Mute != nil {
+		perms |= PermissionVoiceMuteMembers
+	}
+	if p.Deaf != nil {
+		perms |= PermissionVoiceDeafenMembers
+	}
+	if p.CommunicationDisabledUntil != nil {
+		perms |= PermissionModerateMembers
+	}
+	return perms
+}
+
+// nicknamePermission returns the permission needed to change the nickname
+// of the user, which may be @me.
+func nicknamePermission(userID string) Permissions {
+	if userID == "@me" {
+		return PermissionChangeNickname
+	}
+	return PermissionManageNicknames
+}
+
+// reactionRemovePermission returns the permission needed to remove the
+// reaction of the user, which may be @me.
+func reactionRemovePermission(userID string) Permissions {
+	if userID == "@me" {
+		return 0
+	}
+	return PermissionManageMessages
+}
@@ -0,0 +1,137 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPermissions(t *testing.T) {
+	p := Permissions(PermissionViewChannel).Add(PermissionSendMessages | PermissionAttachFiles).Remove(PermissionAttachFiles)
+	if !p.Has(PermissionViewChannel|PermissionSendMessages) || p.Has(PermissionAttachFiles) {
+		t.Errorf("got %v, want ViewChannel|SendMessages", p)
+	}
+	if s := p.String(); s != "ViewChannel|SendMessages" {
+		t.Errorf("String: got %q", s)
+	}
+
+	data, _ := json.Marshal(p)
+	var q Permissions
+	if err := json.Unmarshal(data, &q); err != nil || q != p {
+		t.Errorf("JSON round trip: got %v, %v from %s", q, err, data)
+	}
+}
+
+func testPermissionGuild() *Guild {
+	return &Guild{
+		ID:      "guild",
+		OwnerID: "owner",
+		Roles: []*Role{
+			{ID: "guild", Permissions: PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks},
+			{ID: "mod", Permissions: PermissionKickMembers},
+			{ID: "admin", Permissions: PermissionAdministrator},
+		},
+		Channels: []*Channel{{
+			ID: "parent",
+			PermissionOverwrites: []*PermissionOverwrite{
+				{ID: "guild", Type: PermissionOverwriteTypeRole, Deny: PermissionSendMessages},
+				{ID: "mod", Type: PermissionOverwriteTypeRole, Allow: PermissionSendMessages | PermissionSendMessagesInThreads},
+			},
+		}},
+	}
+}
+
+func TestPermissionCalculator(t *testing.T) {
+	guild := testPermissionGuild()
+	parent := guild.Channels[0]
+	thread := &Channel{ID: "thread", Type: ChannelTypeGuildPublicThread, ParentID: "parent"}
+
+	tests := []struct {
+		name    string
+		member  *Member
+		channel *Channel
+		want    Permissions
+	}{
+		{"guild", &Member{User: &User{ID: "user"}, Roles: []string{"mod"}}, nil,
+			PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionKickMembers},
+		{"everyone overwrite", &Member{User: &User{ID: "user"}}, parent,
+			PermissionViewChannel},
+		{"role overwrite", &Member{User: &User{ID: "user"}, Roles: []string{"mod"}}, parent,
+			PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionKickMembers | PermissionSendMessagesInThreads},
+		{"thread", &Member{User: &User{ID: "user"}, Roles: []string{"mod"}}, thread,
+			PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionKickMembers | PermissionSendMessagesInThreads},
+		{"owner", &Member{User: &User{ID: "owner"}}, parent, PermissionAll},
+		{"admin", &Member{User: &User{ID: "user"}, Roles: []string{"admin"}}, parent, PermissionAll},
+		{"timeout", &Member{
+			User:                       &User{ID: "user"},
+			Roles:                      []string{"mod"},
+			CommunicationDisabledUntil: Timestamp(time.Now().Add(time.Hour).Format(time.RFC3339)),
+		}, parent, PermissionViewChannel},
+	}
+	for _, tt := range tests {
+		c := &PermissionCalculator{Guild: guild, Member: tt.member, Channel: tt.channel}
+		if got := c.Permissions(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPermissionCalculatorExplain(t *testing.T) {
+	guild := testPermissionGuild()
+	c := &PermissionCalculator{Guild: guild, Member: &Member{User: &User{ID: "user"}}, Channel: guild.Channels[0]}
+	e := c.Explain()
+
+	r := e.Reason(PermissionSendMessages)
+	if r.Allowed || r.Source != PermissionSourceOverwrite || r.Overwrite.ID != "guild" {
+		t.Errorf("SendMessages: got %+v, want denied by the @everyone overwrite", r)
+	}
+
+	r = e.Reason(PermissionEmbedLinks)
+	if r.Allowed || r.Source != PermissionSourceImplicit || r.DependsOn != PermissionSendMessages {
+		t.Errorf("EmbedLinks: got %+v, want denied implicitly by SendMessages", r)
+	}
+
+	r = e.Reason(PermissionViewChannel)
+	if !r.Allowed || r.Source != PermissionSourceRole || r.RoleID != "guild" {
+		t.Errorf("ViewChannel: got %+v, want allowed by the @everyone role", r)
+	}
+}
+
+func TestSessionPermissionChecks(t *testing.T) {
+	var requests int
+	s, closeServer := newTestSession(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id": "message"}`))
+	})
+	defer closeServer()
+
+	guild := testPermissionGuild()
+	guild.Channels = nil
+	s.PermissionChecks = true
+	s.State.User = &User{ID: "bot"}
+	s.State.GuildAdd(guild)
+	s.State.ChannelAdd(&Channel{ID: "channel", GuildID: "guild"})
+	s.State.MemberAdd(&Member{GuildID: "guild", User: &User{ID: "bot"}})
+
+	_, err := s.ChannelMessageSendComplex("channel", &MessageSend{
+		Content: "hello",
+		Files:   []*File{{Name: "a.txt", Reader: strings.NewReader("a")}},
+	})
+	perr, ok := err.(*MissingPermissionsError)
+	if !ok || perr.Missing != PermissionAttachFiles {
+		t.Errorf("got error %v, want missing AttachFiles", err)
+	}
+	if requests != 0 {
+		t.Errorf("request was sent despite missing permissions")
+	}
+
+	// Channels which are not in the state are not checked.
+	if _, err := s.ChannelMessageSend("other", "hello"); err != nil {
+		t.Errorf("unchecked request returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("unchecked request was not sent")
+	}
+}
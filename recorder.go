@@ -0,0 +1,246 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the EventRecorder, which records the gateway events
+// of a session, and Replay, which feeds a recording back into a session.
+
+package discordgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedactedToken replaces the tokens in recorded events.
+const RedactedToken = "[REDACTED]"
+
+// An EventRecord is a gateway event in a recording, one JSON object per
+// line.
+type EventRecord struct {
+	Time      time.Time       `json:"time"`
+	Operation int             `json:"op"`
+	Sequence  int64           `json:"s,omitempty"`
+	Type      string          `json:"t,omitempty"`
+	RawData   json.RawMessage `json:"d,omitempty"`
+}
+
+// An EventRecorder writes the gateway events received by a session to a
+// recording, which Replay can feed back into a session. The values of
+// "token" fields, like the tokens of interactions and webhooks, and the
+// token of the session are replaced by RedactedToken. Set it as
+// Session.Recorder to start recording.
+type EventRecorder struct {
+	sync.Mutex
+
+	w   io.Writer
+	c   io.Closer
+	err error
+}
+
+// NewEventRecorder returns an EventRecorder which writes to w.
+func NewEventRecorder(w io.Writer) *EventRecorder {
+	return &EventRecorder{w: w}
+}
+
+// CreateEventRecorder returns an EventRecorder which writes to a new file,
+// or truncates the file if it exists.
+// name : The name of the file.
+func CreateEventRecorder(name string) (*EventRecorder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return &EventRecorder{w: f, c: f}, nil
+}
+
+// Err returns the first error which occurred while writing the recording.
+// The recorder stops recording after an error.
+func (r *EventRecorder) Err() error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.err
+}
+
+// Close stops recording, and closes the file of a recorder created with
+// CreateEventRecorder.
+func (r *EventRecorder) Close() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.err == nil {
+		r.err = os.ErrClosed
+	}
+	if r.c == nil {
+		return nil
+	}
+	c := r.c
+	r.c = nil
+	return c.Close()
+}
+
+// record writes an event received by s to the recording.
+func (r *EventRecorder) record(s *Session, e *Event) {
+	rec := &EventRecord{
+		Time:      time.Now().UTC(),
+		Operation: e.Operation,
+		Sequence:  e.Sequence,
+		Type:      e.Type,
+		RawData:   redactTokens(e.RawData, s.Token),
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		s.log(LogError, "error marshalling event record, %s", err)
+		return
+	}
+	b = append(b, '\n')
+
+	r.Lock()
+	defer r.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	if _, r.err = r.w.Write(b); r.err != nil {
+		s.log(LogError, "error writing event record, %s", r.err)
+	}
+}
+
+// redactTokens replaces the values of "token" fields and the given session
+// token in raw JSON by RedactedToken.
+func redactTokens(raw json.RawMessage, token string) json.RawMessage {
+	token = strings.TrimPrefix(token, "Bot ")
+	if token != "" && bytes.Contains(raw, []byte(token)) {
+		raw = bytes.Replace(raw, []byte(token), []byte(RedactedToken), -1)
+	}
+	if !bytes.Contains(raw, []byte(`"token"`)) {
+		return raw
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return raw
+	}
+	redactTokenFields(v)
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return raw
+	}
+	return b
+}
+
+func redactTokenFields(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, f := range t {
+			if _, ok := f.(string); ok && k == "token" {
+				t[k] = RedactedToken
+				continue
+			}
+			redactTokenFields(f)
+		}
+	case []interface{}:
+		for _, f := range t {
+			redactTokenFields(f)
+		}
+	}
+}
+
+// ReplayOptions are the options of Replay.
+type ReplayOptions struct {
+	// The speed of the replay relative to the recording, e.g. 1 replays
+	// the events in real time and 2 twice as fast. Zero replays them as
+	// fast as possible.
+	Speed float64
+}
+
+// Replay feeds the dispatch events of a recording into the session, as if
+// they were received from the gateway, without a network connection. They
+// update the state and call the event handlers like live events; set
+// SyncEvents for the handlers to run in the order of the events. Other
+// operations, like heartbeats and reconnects, are skipped. The gateway
+// session, which Open resumes, is kept and the Recorder is bypassed.
+// r       : The recording, as written by an EventRecorder.
+// options : The options of the replay, nil replays as fast as possible.
+func (s *Session) Replay(ctx context.Context, r io.Reader, options *ReplayOptions) error {
+	var speed float64
+	if options != nil {
+		speed = options.Speed
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64<<20)
+
+	var last time.Time
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var rec EventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.Operation != 0 {
+			continue
+		}
+
+		if speed > 0 && !last.IsZero() && rec.Time.After(last) {
+			timer := time.NewTimer(time.Duration(float64(rec.Time.Sub(last)) / speed))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		last = rec.Time
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		s.replayEvent(&Event{
+			Operation: rec.Operation,
+			Sequence:  rec.Sequence,
+			Type:      rec.Type,
+			RawData:   rec.RawData,
+		})
+	}
+	return scanner.Err()
+}
+
+// replayEvent dispatches a recorded event. A recorded READY would replace
+// the gateway session, which is restored after it.
+func (s *Session) replayEvent(e *Event) {
+	if e.Type != "READY" {
+		s.dispatchEvent(e)
+		return
+	}
+
+	s.gatewaySessionMu.RLock()
+	sessionID, resumeGatewayURL := s.sessionID, s.resumeGatewayURL
+	s.gatewaySessionMu.RUnlock()
+
+	s.dispatchEvent(e)
+
+	s.gatewaySessionMu.Lock()
+	s.sessionID, s.resumeGatewayURL = sessionID, resumeGatewayURL
+	s.gatewaySessionMu.Unlock()
+}
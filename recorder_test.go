@@ -0,0 +1,91 @@
+package discordgo
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestEventRecorder(t *testing.T) {
+	s, _ := New("Bot secret")
+	var buf bytes.Buffer
+	s.Recorder = NewEventRecorder(&buf)
+
+	for _, m := range []string{
+		`{"op":0,"s":1,"t":"READY","d":{"session_id":"abc","user":{"id":"1"},"guilds":[]}}`,
+		`{"op":0,"s":2,"t":"INTERACTION_CREATE","d":{"id":"i","type":3,"token":"interaction-token","data":{"custom_id":"secret"}}}`,
+		`{"op":11}`,
+	} {
+		if _, err := s.onEvent(websocket.TextMessage, []byte(m)); err != nil {
+			t.Fatalf("onEvent(%s): %v", m, err)
+		}
+	}
+
+	recording := buf.String()
+	if lines := strings.Count(recording, "\n"); lines != 3 {
+		t.Errorf("got %d records, want 3", lines)
+	}
+	if strings.Contains(recording, "interaction-token") || strings.Contains(recording, "secret") {
+		t.Errorf("recording contains a token:\n%s", recording)
+	}
+	if !strings.Contains(recording, `"token":"`+RedactedToken+`"`) || !strings.Contains(recording, `"time":"`) {
+		t.Errorf("recording lacks the redacted token or timestamps:\n%s", recording)
+	}
+
+	s.Recorder.Close()
+	s.onEvent(websocket.TextMessage, []byte(`{"op":11}`))
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("got %d records after Close, want 3", lines)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	recording := `{"time":"2021-01-01T00:00:00Z","op":10,"d":{"heartbeat_interval":41250}}
+{"time":"2021-01-01T00:00:00Z","op":0,"s":1,"t":"READY","d":{"session_id":"abc","user":{"id":"1"},"guilds":[]}}
+{"time":"2021-01-01T00:00:01Z","op":0,"s":2,"t":"GUILD_CREATE","d":{"id":"g","name":"Guild","channels":[{"id":"c","type":0}]}}
+{"time":"2021-01-01T00:00:01Z","op":7}
+{"time":"2021-01-01T00:00:02Z","op":0,"s":3,"t":"MESSAGE_CREATE","d":{"id":"m","channel_id":"c","content":"hello"}}
+`
+
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+	var messages []string
+	s.AddHandler(func(_ *Session, m *MessageCreate) { messages = append(messages, m.Content) })
+
+	// The live session and the recorder are not touched by the replay.
+	live := &GatewaySession{ShardCount: 1, SessionID: "live", Sequence: 42, ResumeGatewayURL: "wss://resume"}
+	if err := s.RestoreGatewaySession(live); err != nil {
+		t.Fatalf("RestoreGatewaySession: %v", err)
+	}
+	var recorded bytes.Buffer
+	s.Recorder = NewEventRecorder(&recorded)
+
+	if err := s.Replay(context.Background(), strings.NewReader(recording), nil); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0] != "hello" {
+		t.Errorf("got messages %q, want [hello]", messages)
+	}
+	if c, err := s.State.Channel("c"); err != nil || c.GuildID != "g" {
+		t.Errorf("got channel %+v, %v, want channel c of guild g in the state", c, err)
+	}
+	if gs := s.GatewaySession(); *gs != *live {
+		t.Errorf("got gateway session %+v after the replay, want %+v", gs, live)
+	}
+	if recorded.Len() != 0 {
+		t.Errorf("replayed events were recorded:\n%s", recorded.String())
+	}
+
+	// Replaying in real time is cut short by the context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := s.Replay(ctx, strings.NewReader(recording), &ReplayOptions{Speed: 1})
+	if err != context.DeadlineExceeded {
+		t.Errorf("real time replay: got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // All error constants
@@ -37,15 +38,60 @@ var (
 	ErrGuildNoIcon             = errors.New("guild does not have an icon set")
 	ErrGuildNoSplash           = errors.New("guild does not have a splash set")
 	ErrUnauthorized            = errors.New("HTTP request was unauthorized. This could be because the provided token was not a bot token. Please add \"Bot \" to the start of your token. https://discord.com/developers/docs/reference#authentication-example-bot-token-authorization-header")
+	ErrAuditLogReasonTooLong   = errors.New("audit log reason exceeds AuditLogReasonLimit characters")
 )
 
+// ErrEditFileDescription is returned when a message is edited with a File
+// which has a Description, but without Attachments. The description is
+// sent in the attachments list, which also removes the attachments it
+// does not list, so they have to be listed to be kept.
+var ErrEditFileDescription = errors.New("a File with a Description can only be added to a message by listing the Attachments to keep")
+
+// AuditLogReasonLimit is the maximum length of an audit log reason, in characters.
+const AuditLogReasonLimit = 512
+
+// RequestConfig is the configuration of a single REST request, which can
+// be changed with RequestOptions.
+type RequestConfig struct {
+	Request *http.Request
+
+	// err is set by options which are invalid, the request is not sent.
+	err error
+}
+
+// RequestOption is a function which changes the configuration of a REST
+// request. Options are applied again when a request is retried.
+// Every Session method which creates, edits or deletes something accepts
+// RequestOptions, e.g. to set the audit log reason with WithAuditLogReason.
+type RequestOption func(cfg *RequestConfig)
+
+// WithHeader sets a header of the request.
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *RequestConfig) {
+		cfg.Request.Header.Set(key, value)
+	}
+}
+
+// WithAuditLogReason sets the reason shown in the audit log entry of the
+// request. The request fails with ErrAuditLogReasonTooLong if reason is
+// longer than AuditLogReasonLimit characters.
+func WithAuditLogReason(reason string) RequestOption {
+	return func(cfg *RequestConfig) {
+		if utf8.RuneCountInString(reason) > AuditLogReasonLimit {
+			cfg.err = ErrAuditLogReasonTooLong
+			return
+		}
+		cfg.Request.Header.Set("X-Audit-Log-Reason", url.PathEscape(reason))
+	}
+}
+
 // Request is the same as RequestWithBucketID but the bucket id is the same as the urlStr
-func (s *Session) Request(method, urlStr string, data interface{}) (response []byte, err error) {
-	return s.RequestWithBucketID(method, urlStr, data, strings.SplitN(urlStr, "?", 2)[0])
+func (s *Session) Request(method, urlStr string, data interface{}, options ...RequestOption) (response []byte, err error) {
+	return s.RequestWithBucketID(method, urlStr, data, strings.SplitN(urlStr, "?", 2)[0], options...)
 }
 
 // RequestWithBucketID makes a (GET/POST/...) Requests to Discord REST API with JSON data.
-func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, bucketID string) (response []byte, err error) {
+func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, bucketID string, options ...RequestOption) (response []byte, err error) {
 	var body []byte
 	if data != nil {
 		body, err = json.Marshal(data)
@@ -54,28 +100,70 @@ func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, b
 		}
 	}
 
-	return s.request(method, urlStr, "application/json", body, bucketID, 0)
+	return s.request(method, urlStr, "application/json", body, bucketID, 0, options...)
 }
 
 // request makes a (GET/POST/...) Requests to Discord REST API.
 // Sequence is the sequence number, if it fails with a 502 it will
 // retry with sequence+1 until it either succeeds or sequence >= session.MaxRestRetries
-func (s *Session) request(method, urlStr, contentType string, b []byte, bucketID string, sequence int) (response []byte, err error) {
+func (s *Session) request(method, urlStr, contentType string, b []byte, bucketID string, sequence int, options ...RequestOption) (response []byte, err error) {
 	if bucketID == "" {
 		bucketID = strings.SplitN(urlStr, "?", 2)[0]
 	}
-	return s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucket(bucketID), sequence)
+	return s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucket(bucketID), sequence, options...)
+}
+
+// requestWithFiles makes a multipart request to Discord REST API with JSON
+// data and files. The files are streamed instead of being buffered in memory.
+func (s *Session) requestWithFiles(method, urlStr string, data interface{}, files []*File, bucketID string, options ...RequestOption) (response []byte, err error) {
+	contentType, body, err := multipartStreamWithJSON(data, files)
+	if err != nil {
+		return
+	}
+
+	if s.Debug {
+		log.Printf("API REQUEST %8s :: %s\n", method, urlStr)
+		log.Printf("API REQUEST  PAYLOAD :: [multipart with %d files]\n", len(files))
+	}
+
+	return s.requestWithLockedBucket(method, urlStr, contentType, body, s.Ratelimiter.LockBucket(bucketID), 0, options)
 }
 
 // RequestWithLockedBucket makes a request using a bucket that's already been locked
-func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b []byte, bucket *Bucket, sequence int) (response []byte, err error) {
+func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b []byte, bucket *Bucket, sequence int, options ...RequestOption) (response []byte, err error) {
 	if s.Debug {
 		log.Printf("API REQUEST %8s :: %s\n", method, urlStr)
 		log.Printf("API REQUEST  PAYLOAD :: [%s]\n", string(b))
 	}
 
-	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(b))
+	var body func() (io.Reader, error)
+	if b != nil {
+		body = func() (io.Reader, error) {
+			return bytes.NewReader(b), nil
+		}
+	}
+
+	return s.requestWithLockedBucket(method, urlStr, contentType, body, bucket, sequence, options)
+}
+
+// requestWithLockedBucket makes a request using a bucket that's already been
+// locked. body is called for every attempt to create the request body, a nil
+// body sends the request without one.
+func (s *Session) requestWithLockedBucket(method, urlStr, contentType string, body func() (io.Reader, error), bucket *Bucket, sequence int, options []RequestOption) (response []byte, err error) {
+	var r io.Reader
+	if body != nil {
+		r, err = body()
+		if err != nil {
+			bucket.Release(nil)
+			return
+		}
+	}
+
+	req, err := http.NewRequest(method, urlStr, r)
 	if err != nil {
+		if c, ok := r.(io.Closer); ok {
+			c.Close()
+		}
 		bucket.Release(nil)
 		return
 	}
@@ -88,13 +176,26 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 
 	// Discord's API returns a 400 Bad Request is Content-Type is set, but the
 	// request body is empty.
-	if b != nil {
+	if body != nil {
 		req.Header.Set("Content-Type", contentType)
 	}
 
 	// TODO: Make a configurable static variable.
 	req.Header.Set("User-Agent", s.UserAgent)
 
+	cfg := &RequestConfig{Request: req}
+	for _, opt := range options {
+		opt(cfg)
+	}
+	req = cfg.Request
+	if cfg.err != nil {
+		if c, ok := r.(io.Closer); ok {
+			c.Close()
+		}
+		bucket.Release(nil)
+		return nil, cfg.err
+	}
+
 	if s.Debug {
 		for k, v := range req.Header {
 			log.Printf("API REQUEST   HEADER :: [%s] = %+v\n", k, v)
@@ -141,7 +242,7 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		if sequence < s.MaxRestRetries {
 
 			s.log(LogInformational, "%s Failed (%s), Retrying...", urlStr, resp.Status)
-			response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence+1)
+			response, err = s.requestWithLockedBucket(method, urlStr, contentType, body, s.Ratelimiter.LockBucketObject(bucket), sequence+1, options)
 		} else {
 			err = fmt.Errorf("Exceeded Max retries HTTP %s, %s", resp.Status, response)
 		}
@@ -159,7 +260,7 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		// we can make the above smarter
 		// this method can cause longer delays than required
 
-		response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence)
+		response, err = s.requestWithLockedBucket(method, urlStr, contentType, body, s.Ratelimiter.LockBucketObject(bucket), sequence, options)
 	case http.StatusUnauthorized:
 		if strings.Index(s.Token, "Bot ") != 0 {
 			s.log(LogInformational, ErrUnauthorized.Error())
@@ -193,14 +294,14 @@ func unmarshal(data []byte, v interface{}) error {
 // and then use that authentication token for all future connections.
 // Also, doing any form of automation with a user (non Bot) account may result
 // in that account being permanently banned from Discord.
-func (s *Session) Login(email, password string) (err error) {
+func (s *Session) Login(email, password string, options ...RequestOption) (err error) {
 
 	data := struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
 	}{email, password}
 
-	response, err := s.RequestWithBucketID("POST", EndpointLogin, data, EndpointLogin)
+	response, err := s.RequestWithBucketID("POST", EndpointLogin, data, EndpointLogin, options...)
 	if err != nil {
 		return
 	}
@@ -223,13 +324,13 @@ func (s *Session) Login(email, password string) (err error) {
 // Register sends a Register request to Discord, and returns the authentication token
 // Note that this account is temporary and should be verified for future use.
 // Another option is to save the authentication token external, but this isn't recommended.
-func (s *Session) Register(username string) (token string, err error) {
+func (s *Session) Register(username string, options ...RequestOption) (token string, err error) {
 
 	data := struct {
 		Username string `json:"username"`
 	}{username}
 
-	response, err := s.RequestWithBucketID("POST", EndpointRegister, data, EndpointRegister)
+	response, err := s.RequestWithBucketID("POST", EndpointRegister, data, EndpointRegister, options...)
 	if err != nil {
 		return
 	}
@@ -251,7 +352,7 @@ func (s *Session) Register(username string) (token string, err error) {
 // This does not seem to actually invalidate the token.  So you can still
 // make API calls even after a Logout.  So, it seems almost pointless to
 // even use.
-func (s *Session) Logout() (err error) {
+func (s *Session) Logout(options ...RequestOption) (err error) {
 
 	//  _, err = s.Request("POST", LOGOUT, `{"token": "` + s.Token + `"}`)
 
@@ -263,7 +364,7 @@ func (s *Session) Logout() (err error) {
 		Token string `json:"token"`
 	}{s.Token}
 
-	_, err = s.RequestWithBucketID("POST", EndpointLogout, data, EndpointLogout)
+	_, err = s.RequestWithBucketID("POST", EndpointLogout, data, EndpointLogout, options...)
 	return
 }
 
@@ -308,7 +409,7 @@ func (s *Session) UserAvatarDecode(u *User) (img image.Image, err error) {
 }
 
 // UserUpdate updates a users settings.
-func (s *Session) UserUpdate(email, password, username, avatar, newPassword string) (st *User, err error) {
+func (s *Session) UserUpdate(email, password, username, avatar, newPassword string, options ...RequestOption) (st *User, err error) {
 
 	// NOTE: Avatar must be either the hash/id of existing Avatar or
 	// data:image/png;base64,BASE64_STRING_OF_NEW_AVATAR_PNG
@@ -323,7 +424,7 @@ func (s *Session) UserUpdate(email, password, username, avatar, newPassword stri
 		NewPassword string `json:"new_password,omitempty"`
 	}{email, password, username, avatar, newPassword}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointUser("@me"), data, EndpointUsers)
+	body, err := s.RequestWithBucketID("PATCH", EndpointUser("@me"), data, EndpointUsers, options...)
 	if err != nil {
 		return
 	}
@@ -346,7 +447,7 @@ func (s *Session) UserSettings() (st *Settings, err error) {
 
 // UserUpdateStatus update the user status
 // status   : The new status (Actual valid status are 'online','idle','dnd','invisible')
-func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
+func (s *Session) UserUpdateStatus(status Status, options ...RequestOption) (st *Settings, err error) {
 	if status == StatusOffline {
 		err = ErrStatusOffline
 		return
@@ -356,7 +457,7 @@ func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
 		Status Status `json:"status"`
 	}{status}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointUserSettings("@me"), data, EndpointUserSettings(""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointUserSettings("@me"), data, EndpointUserSettings(""), options...)
 	if err != nil {
 		return
 	}
@@ -395,13 +496,13 @@ func (s *Session) UserChannels() (st []*Channel, err error) {
 
 // UserChannelCreate creates a new User (Private) Channel with another User
 // recipientID : A user ID for the user to which this channel is opened with.
-func (s *Session) UserChannelCreate(recipientID string) (st *Channel, err error) {
+func (s *Session) UserChannelCreate(recipientID string, options ...RequestOption) (st *Channel, err error) {
 
 	data := struct {
 		RecipientID string `json:"recipient_id"`
 	}{recipientID}
 
-	body, err := s.RequestWithBucketID("POST", EndpointUserChannels("@me"), data, EndpointUserChannels(""))
+	body, err := s.RequestWithBucketID("POST", EndpointUserChannels("@me"), data, EndpointUserChannels(""), options...)
 	if err != nil {
 		return
 	}
@@ -446,9 +547,9 @@ func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGui
 // UserGuildSettingsEdit Edits the users notification settings for a guild
 // guildID   : The ID of the guild to edit the settings on
 // settings  : The settings to update
-func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSettingsEdit) (st *UserGuildSettings, err error) {
+func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSettingsEdit, options ...RequestOption) (st *UserGuildSettings, err error) {
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointUserGuildSettings("@me", guildID), settings, EndpointUserGuildSettings("", guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointUserGuildSettings("@me", guildID), settings, EndpointUserGuildSettings("", guildID), options...)
 	if err != nil {
 		return
 	}
@@ -500,74 +601,17 @@ func (s *Session) UserChannelPermissions(userID, channelID string) (apermissions
 		}
 	}
 
-	return memberPermissions(guild, channel, userID, member.Roles), nil
+	return memberPermissions(guild, channel, userID, member), nil
 }
 
 // Calculates the permissions for a member.
 // https://support.discord.com/hc/en-us/articles/206141927-How-is-the-permission-hierarchy-structured-
-func memberPermissions(guild *Guild, channel *Channel, userID string, roles []string) (apermissions int64) {
-	if userID == guild.OwnerID {
-		apermissions = PermissionAll
-		return
-	}
-
-	for _, role := range guild.Roles {
-		if role.ID == guild.ID {
-			apermissions |= role.Permissions
-			break
-		}
-	}
-
-	for _, role := range guild.Roles {
-		for _, roleID := range roles {
-			if role.ID == roleID {
-				apermissions |= role.Permissions
-				break
-			}
-		}
-	}
-
-	if apermissions&PermissionAdministrator == PermissionAdministrator {
-		apermissions |= PermissionAll
-	}
-
-	// Apply @everyone overrides from the channel.
-	for _, overwrite := range channel.PermissionOverwrites {
-		if guild.ID == overwrite.ID {
-			apermissions &= ^overwrite.Deny
-			apermissions |= overwrite.Allow
-			break
-		}
-	}
-
-	var denies, allows int64
-	// Member overwrites can override role overrides, so do two passes
-	for _, overwrite := range channel.PermissionOverwrites {
-		for _, roleID := range roles {
-			if overwrite.Type == PermissionOverwriteTypeRole && roleID == overwrite.ID {
-				denies |= overwrite.Deny
-				allows |= overwrite.Allow
-				break
-			}
-		}
-	}
-
-	apermissions &= ^denies
-	apermissions |= allows
-
-	for _, overwrite := range channel.PermissionOverwrites {
-		if overwrite.Type == PermissionOverwriteTypeMember && overwrite.ID == userID {
-			apermissions &= ^overwrite.Deny
-			apermissions |= overwrite.Allow
-			break
-		}
-	}
-
-	if apermissions&PermissionAdministrator == PermissionAdministrator {
-		apermissions |= PermissionAllChannel
-	}
+func memberPermissions(guild *Guild, channel *Channel, userID string, member *Member) (apermissions int64) {
+	m := *member
+	m.User = &User{ID: userID}
 
-	return apermissions
+	c := &PermissionCalculator{Guild: guild, Member: &m, Channel: channel}
+	return int64(c.Permissions())
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -600,13 +644,13 @@ func (s *Session) GuildPreview(guildID string) (st *GuildPreview, err error) {
 
 // GuildCreate creates a new Guild
 // name      : A name for the Guild (2-100 characters)
-func (s *Session) GuildCreate(name string) (st *Guild, err error) {
+func (s *Session) GuildCreate(name string, options ...RequestOption) (st *Guild, err error) {
 
 	data := struct {
 		Name string `json:"name"`
 	}{name}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildCreate, data, EndpointGuildCreate)
+	body, err := s.RequestWithBucketID("POST", EndpointGuildCreate, data, EndpointGuildCreate, options...)
 	if err != nil {
 		return
 	}
@@ -618,7 +662,11 @@ func (s *Session) GuildCreate(name string) (st *Guild, err error) {
 // GuildEdit edits a new Guild
 // guildID   : The ID of a Guild
 // g 		 : A GuildParams struct with the values Name, Region and VerificationLevel defined.
-func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error) {
+func (s *Session) GuildEdit(guildID string, g GuildParams, options ...RequestOption) (st *Guild, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
 	// Bounds checking for VerificationLevel, interval: [0, 4]
 	if g.VerificationLevel != nil {
@@ -648,7 +696,7 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 		}
 	}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuild(guildID), g, EndpointGuild(guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuild(guildID), g, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -659,9 +707,9 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 
 // GuildDelete deletes a Guild.
 // guildID   : The ID of a Guild
-func (s *Session) GuildDelete(guildID string) (st *Guild, err error) {
+func (s *Session) GuildDelete(guildID string, options ...RequestOption) (st *Guild, err error) {
 
-	body, err := s.RequestWithBucketID("DELETE", EndpointGuild(guildID), nil, EndpointGuild(guildID))
+	body, err := s.RequestWithBucketID("DELETE", EndpointGuild(guildID), nil, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -672,9 +720,9 @@ func (s *Session) GuildDelete(guildID string) (st *Guild, err error) {
 
 // GuildLeave leaves a Guild.
 // guildID   : The ID of a Guild
-func (s *Session) GuildLeave(guildID string) (err error) {
+func (s *Session) GuildLeave(guildID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointUserGuild("@me", guildID), nil, EndpointUserGuild("", guildID))
+	_, err = s.RequestWithBucketID("DELETE", EndpointUserGuild("@me", guildID), nil, EndpointUserGuild("", guildID), options...)
 	return
 }
 
@@ -682,8 +730,38 @@ func (s *Session) GuildLeave(guildID string) (err error) {
 // given guild.
 // guildID   : The ID of a Guild.
 func (s *Session) GuildBans(guildID string) (st []*GuildBan, err error) {
+	return s.guildBans(guildID, 0, "", "")
+}
+
+// guildBans returns a page of the bans of a guild.
+// Use GuildBansIterator to walk all bans.
+// guildID   : The ID of a Guild.
+// limit     : The number of bans to return (max 1000)
+// beforeID  : If provided all bans returned will be before given user ID.
+// afterID   : If provided all bans returned will be after given user ID.
+func (s *Session) guildBans(guildID string, limit int, beforeID, afterID string) (st []*GuildBan, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionBanMembers); err != nil {
+		return
+	}
+
+	uri := EndpointGuildBans(guildID)
+
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if afterID != "" {
+		v.Set("after", afterID)
+	}
+	if beforeID != "" {
+		v.Set("before", beforeID)
+	}
+	if len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
 
-	body, err := s.RequestWithBucketID("GET", EndpointGuildBans(guildID), nil, EndpointGuildBans(guildID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildBans(guildID))
 	if err != nil {
 		return
 	}
@@ -697,8 +775,8 @@ func (s *Session) GuildBans(guildID string) (st []*GuildBan, err error) {
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
 // days      : The number of days of previous comments to delete.
-func (s *Session) GuildBanCreate(guildID, userID string, days int) (err error) {
-	return s.GuildBanCreateWithReason(guildID, userID, "", days)
+func (s *Session) GuildBanCreate(guildID, userID string, days int, options ...RequestOption) (err error) {
+	return s.GuildBanCreateWithReason(guildID, userID, "", days, options...)
 }
 
 // GuildBan finds ban by given guild and user id and returns GuildBan structure
@@ -719,7 +797,11 @@ func (s *Session) GuildBan(guildID, userID string) (st *GuildBan, err error) {
 // userID    : The ID of a User
 // reason    : The reason for this ban
 // days      : The number of days of previous comments to delete.
-func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days int) (err error) {
+func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days int, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionBanMembers); err != nil {
+		return
+	}
 
 	uri := EndpointGuildBan(guildID, userID)
 
@@ -728,23 +810,27 @@ func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days
 		queryParams.Set("delete_message_days", strconv.Itoa(days))
 	}
 	if reason != "" {
-		queryParams.Set("reason", reason)
+		options = append([]RequestOption{WithAuditLogReason(reason)}, options...)
 	}
 
 	if len(queryParams) > 0 {
 		uri += "?" + queryParams.Encode()
 	}
 
-	_, err = s.RequestWithBucketID("PUT", uri, nil, EndpointGuildBan(guildID, ""))
+	_, err = s.RequestWithBucketID("PUT", uri, nil, EndpointGuildBan(guildID, ""), options...)
 	return
 }
 
 // GuildBanDelete removes the given user from the guild bans
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
-func (s *Session) GuildBanDelete(guildID, userID string) (err error) {
+func (s *Session) GuildBanDelete(guildID, userID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionBanMembers); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""), options...)
 	return
 }
 
@@ -801,7 +887,7 @@ func (s *Session) GuildMember(guildID, userID string) (st *Member, err error) {
 //  roles         : A list of role ID's to set on the member.
 //  mute          : If the user is muted.
 //  deaf          : If the user is deafened.
-func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, roles []string, mute, deaf bool) (err error) {
+func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, roles []string, mute, deaf bool, options ...RequestOption) (err error) {
 
 	data := struct {
 		AccessToken string   `json:"access_token"`
@@ -811,7 +897,7 @@ func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, role
 		Deaf        bool     `json:"deaf,omitempty"`
 	}{accessToken, nick, roles, mute, deaf}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	if err != nil {
 		return err
 	}
@@ -822,23 +908,26 @@ func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, role
 // GuildMemberDelete removes the given user from the given guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
-func (s *Session) GuildMemberDelete(guildID, userID string) (err error) {
+func (s *Session) GuildMemberDelete(guildID, userID string, options ...RequestOption) (err error) {
 
-	return s.GuildMemberDeleteWithReason(guildID, userID, "")
+	return s.GuildMemberDeleteWithReason(guildID, userID, "", options...)
 }
 
 // GuildMemberDeleteWithReason removes the given user from the given guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
 // reason    : The reason for the kick
-func (s *Session) GuildMemberDeleteWithReason(guildID, userID, reason string) (err error) {
+func (s *Session) GuildMemberDeleteWithReason(guildID, userID, reason string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionKickMembers); err != nil {
+		return
+	}
 
-	uri := EndpointGuildMember(guildID, userID)
 	if reason != "" {
-		uri += "?reason=" + url.QueryEscape(reason)
+		options = append([]RequestOption{WithAuditLogReason(reason)}, options...)
 	}
 
-	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMember(guildID, userID), nil, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -846,13 +935,54 @@ func (s *Session) GuildMemberDeleteWithReason(guildID, userID, reason string) (e
 // guildID  : The ID of a Guild.
 // userID   : The ID of a User.
 // roles    : A list of role ID's to set on the member.
-func (s *Session) GuildMemberEdit(guildID, userID string, roles []string) (err error) {
+func (s *Session) GuildMemberEdit(guildID, userID string, roles []string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
 
 	data := struct {
 		Roles []string `json:"roles"`
 	}{roles}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
+	return
+}
+
+// GuildMemberEditComplex edits a guild member and returns the updated member.
+// guildID  : The ID of a Guild.
+// userID   : The ID of a User.
+// data     : The fields of the member to change.
+// options  : Options of the request, e.g. WithAuditLogReason.
+func (s *Session) GuildMemberEditComplex(guildID, userID string, data *GuildMemberParams, options ...RequestOption) (st *Member, err error) {
+
+	if err = s.checkGuildPermissions(guildID, data.requiredPermissions(userID)); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// GuildMemberTimeout times out a guild member, so they can't communicate in
+// the guild until the timeout expires.
+// guildID  : The ID of a Guild.
+// userID   : The ID of a User.
+// until    : When the timeout expires, at most 28 days from now. nil removes the timeout.
+// options  : Options of the request, e.g. WithAuditLogReason.
+func (s *Session) GuildMemberTimeout(guildID, userID string, until *time.Time, options ...RequestOption) (err error) {
+
+	data := &GuildMemberParams{CommunicationDisabledUntil: &time.Time{}}
+	if until != nil {
+		data.CommunicationDisabledUntil = until
+	}
+
+	_, err = s.GuildMemberEditComplex(guildID, userID, data, options...)
 	return
 }
 
@@ -862,12 +992,16 @@ func (s *Session) GuildMemberEdit(guildID, userID string, roles []string) (err e
 //  channelID : The ID of a channel to move user to or nil to remove from voice channel
 // NOTE : I am not entirely set on the name of this function and it may change
 // prior to the final 1.0.0 release of Discordgo
-func (s *Session) GuildMemberMove(guildID string, userID string, channelID *string) (err error) {
+func (s *Session) GuildMemberMove(guildID string, userID string, channelID *string, options ...RequestOption) (err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionVoiceMoveMembers); err != nil {
+		return
+	}
+
 	data := struct {
 		ChannelID *string `json:"channel_id"`
 	}{channelID}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -876,7 +1010,11 @@ func (s *Session) GuildMemberMove(guildID string, userID string, channelID *stri
 // userID    : The ID of a user
 // userID    : The ID of a user or "@me" which is a shortcut of the current user ID
 // nickname  : The nickname of the member, "" will reset their nickname
-func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err error) {
+func (s *Session) GuildMemberNickname(guildID, userID, nickname string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, nicknamePermission(userID)); err != nil {
+		return
+	}
 
 	data := struct {
 		Nick string `json:"nick"`
@@ -886,7 +1024,7 @@ func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err err
 		userID += "/nick"
 	}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -894,12 +1032,16 @@ func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err err
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  mute    : boolean value for if the user should be muted
-func (s *Session) GuildMemberMute(guildID string, userID string, mute bool) (err error) {
+func (s *Session) GuildMemberMute(guildID string, userID string, mute bool, options ...RequestOption) (err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionVoiceMuteMembers); err != nil {
+		return
+	}
+
 	data := struct {
 		Mute bool `json:"mute"`
 	}{mute}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -907,12 +1049,16 @@ func (s *Session) GuildMemberMute(guildID string, userID string, mute bool) (err
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  deaf    : boolean value for if the user should be deafened
-func (s *Session) GuildMemberDeafen(guildID string, userID string, deaf bool) (err error) {
+func (s *Session) GuildMemberDeafen(guildID string, userID string, deaf bool, options ...RequestOption) (err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionVoiceDeafenMembers); err != nil {
+		return
+	}
+
 	data := struct {
 		Deaf bool `json:"deaf"`
 	}{deaf}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -920,9 +1066,13 @@ func (s *Session) GuildMemberDeafen(guildID string, userID string, deaf bool) (e
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  roleID 	  : The ID of a Role to be assigned to the user.
-func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string) (err error) {
+func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("PUT", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""))
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
+
+	_, err = s.RequestWithBucketID("PUT", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""), options...)
 
 	return
 }
@@ -931,9 +1081,13 @@ func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string) (err error)
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  roleID 	  : The ID of a Role to be removed from the user.
-func (s *Session) GuildMemberRoleRemove(guildID, userID, roleID string) (err error) {
+func (s *Session) GuildMemberRoleRemove(guildID, userID, roleID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""), options...)
 
 	return
 }
@@ -970,8 +1124,12 @@ type GuildChannelCreateData struct {
 // GuildChannelCreateComplex creates a new channel in the given guild
 // guildID      : The ID of a Guild
 // data         : A data struct describing the new Channel, Name and Type are mandatory, other fields depending on the type
-func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCreateData) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID))
+func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCreateData, options ...RequestOption) (st *Channel, err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionManageChannels); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("POST", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -984,17 +1142,21 @@ func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCre
 // guildID   : The ID of a Guild.
 // name      : Name of the channel (2-100 chars length)
 // ctype     : Type of the channel
-func (s *Session) GuildChannelCreate(guildID, name string, ctype ChannelType) (st *Channel, err error) {
+func (s *Session) GuildChannelCreate(guildID, name string, ctype ChannelType, options ...RequestOption) (st *Channel, err error) {
 	return s.GuildChannelCreateComplex(guildID, GuildChannelCreateData{
 		Name: name,
 		Type: ctype,
-	})
+	}, options...)
 }
 
 // GuildChannelsReorder updates the order of channels in a guild
 // guildID   : The ID of a Guild.
 // channels  : Updated channels.
-func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel) (err error) {
+func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageChannels); err != nil {
+		return
+	}
 
 	data := make([]struct {
 		ID       string `json:"id"`
@@ -1006,13 +1168,17 @@ func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel) (err
 		data[i].Position = c.Position
 	}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID), options...)
 	return
 }
 
 // GuildInvites returns an array of Invite structures for the given guild
 // guildID   : The ID of a Guild.
 func (s *Session) GuildInvites(guildID string) (st []*Invite, err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointGuildInvites(guildID), nil, EndpointGuildInvites(guildID))
 	if err != nil {
 		return
@@ -1038,9 +1204,13 @@ func (s *Session) GuildRoles(guildID string) (st []*Role, err error) {
 
 // GuildRoleCreate returns a new Guild Role.
 // guildID: The ID of a Guild.
-func (s *Session) GuildRoleCreate(guildID string) (st *Role, err error) {
+func (s *Session) GuildRoleCreate(guildID string, options ...RequestOption) (st *Role, err error) {
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildRoles(guildID), nil, EndpointGuildRoles(guildID))
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("POST", EndpointGuildRoles(guildID), nil, EndpointGuildRoles(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1058,7 +1228,11 @@ func (s *Session) GuildRoleCreate(guildID string) (st *Role, err error) {
 // hoist     : Whether to display the role's users separately.
 // perm      : The permissions for the role.
 // mention   : Whether this role is mentionable
-func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist bool, perm int64, mention bool) (st *Role, err error) {
+func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist bool, perm int64, mention bool, options ...RequestOption) (st *Role, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
 
 	// Prevent sending a color int that is too big.
 	if color > 0xFFFFFF {
@@ -1074,7 +1248,7 @@ func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist b
 		Mentionable bool   `json:"mentionable"`        // Whether this role is mentionable
 	}{name, color, hoist, perm, mention}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRole(guildID, roleID), data, EndpointGuildRole(guildID, ""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRole(guildID, roleID), data, EndpointGuildRole(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1087,9 +1261,13 @@ func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist b
 // GuildRoleReorder reoders guild roles
 // guildID   : The ID of a Guild.
 // roles     : A list of ordered roles.
-func (s *Session) GuildRoleReorder(guildID string, roles []*Role) (st []*Role, err error) {
+func (s *Session) GuildRoleReorder(guildID string, roles []*Role, options ...RequestOption) (st []*Role, err error) {
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRoles(guildID), roles, EndpointGuildRoles(guildID))
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRoles(guildID), roles, EndpointGuildRoles(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1102,9 +1280,13 @@ func (s *Session) GuildRoleReorder(guildID string, roles []*Role) (st []*Role, e
 // GuildRoleDelete deletes an existing role.
 // guildID   : The ID of a Guild.
 // roleID    : The ID of a Role.
-func (s *Session) GuildRoleDelete(guildID, roleID string) (err error) {
+func (s *Session) GuildRoleDelete(guildID, roleID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageRoles); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildRole(guildID, roleID), nil, EndpointGuildRole(guildID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildRole(guildID, roleID), nil, EndpointGuildRole(guildID, ""), options...)
 
 	return
 }
@@ -1114,6 +1296,10 @@ func (s *Session) GuildRoleDelete(guildID, roleID string) (err error) {
 // guildID	: The ID of a Guild.
 // days		: The number of days to count prune for (1 or more).
 func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionKickMembers); err != nil {
+		return
+	}
+
 	count = 0
 
 	if days <= 0 {
@@ -1145,7 +1331,11 @@ func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, er
 // Returns an object with one 'pruned' key indicating the number of members that were removed in the prune operation.
 // guildID	: The ID of a Guild.
 // days		: The number of days to count prune for (1 or more).
-func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err error) {
+func (s *Session) GuildPrune(guildID string, days uint32, options ...RequestOption) (count uint32, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionKickMembers); err != nil {
+		return
+	}
 
 	count = 0
 
@@ -1162,7 +1352,7 @@ func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err err
 		Pruned uint32 `json:"pruned"`
 	}{}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildPrune(guildID), data, EndpointGuildPrune(guildID))
+	body, err := s.RequestWithBucketID("POST", EndpointGuildPrune(guildID), data, EndpointGuildPrune(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1181,6 +1371,10 @@ func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err err
 // guildID   : The ID of a Guild.
 func (s *Session) GuildIntegrations(guildID string) (st []*Integration, err error) {
 
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointGuildIntegrations(guildID), nil, EndpointGuildIntegrations(guildID))
 	if err != nil {
 		return
@@ -1195,14 +1389,18 @@ func (s *Session) GuildIntegrations(guildID string) (st []*Integration, err erro
 // guildID          : The ID of a Guild.
 // integrationType  : The Integration type.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID string) (err error) {
+func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
 	data := struct {
 		Type string `json:"type"`
 		ID   string `json:"id"`
 	}{integrationType, integrationID}
 
-	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrations(guildID), data, EndpointGuildIntegrations(guildID))
+	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrations(guildID), data, EndpointGuildIntegrations(guildID), options...)
 	return
 }
 
@@ -1213,7 +1411,11 @@ func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID
 // expireBehavior	      : The behavior when an integration subscription lapses (see the integration object documentation).
 // expireGracePeriod    : Period (in seconds) where the integration will ignore lapsed subscriptions.
 // enableEmoticons	    : Whether emoticons should be synced for this integration (twitch only currently).
-func (s *Session) GuildIntegrationEdit(guildID, integrationID string, expireBehavior, expireGracePeriod int, enableEmoticons bool) (err error) {
+func (s *Session) GuildIntegrationEdit(guildID, integrationID string, expireBehavior, expireGracePeriod int, enableEmoticons bool, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
 	data := struct {
 		ExpireBehavior    int  `json:"expire_behavior"`
@@ -1221,25 +1423,33 @@ func (s *Session) GuildIntegrationEdit(guildID, integrationID string, expireBeha
 		EnableEmoticons   bool `json:"enable_emoticons"`
 	}{expireBehavior, expireGracePeriod, enableEmoticons}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildIntegration(guildID, integrationID), data, EndpointGuildIntegration(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildIntegration(guildID, integrationID), data, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
 // GuildIntegrationDelete removes the given integration from the Guild.
 // guildID          : The ID of a Guild.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationDelete(guildID, integrationID string) (err error) {
+func (s *Session) GuildIntegrationDelete(guildID, integrationID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildIntegration(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildIntegration(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
 // GuildIntegrationSync syncs an integration.
 // guildID          : The ID of a Guild.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationSync(guildID, integrationID string) (err error) {
+func (s *Session) GuildIntegrationSync(guildID, integrationID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrationSync(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""))
+	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrationSync(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
@@ -1302,11 +1512,15 @@ func (s *Session) GuildEmbed(guildID string) (st *GuildEmbed, err error) {
 
 // GuildEmbedEdit returns the embed for a Guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildEmbedEdit(guildID string, enabled bool, channelID string) (err error) {
+func (s *Session) GuildEmbedEdit(guildID string, enabled bool, channelID string, options ...RequestOption) (err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageServer); err != nil {
+		return
+	}
 
 	data := GuildEmbed{enabled, channelID}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildEmbed(guildID), data, EndpointGuildEmbed(guildID))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildEmbed(guildID), data, EndpointGuildEmbed(guildID), options...)
 	return
 }
 
@@ -1318,6 +1532,10 @@ func (s *Session) GuildEmbedEdit(guildID string, enabled bool, channelID string)
 // limit       : The number messages that can be returned. (default 50, min 1, max 100)
 func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, limit int) (st *GuildAuditLog, err error) {
 
+	if err = s.checkGuildPermissions(guildID, PermissionViewAuditLogs); err != nil {
+		return
+	}
+
 	uri := EndpointGuildAuditLogs(guildID)
 
 	v := url.Values{}
@@ -1346,6 +1564,49 @@ func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, li
 	return
 }
 
+// GuildAuditLogComplex returns a page of the audit log of a guild.
+// Use GuildAuditLogIterator to walk the whole audit log.
+// guildID : The ID of a Guild.
+// params  : The parameters of the request, may be nil.
+func (s *Session) GuildAuditLogComplex(guildID string, params *GuildAuditLogParams, options ...RequestOption) (st *GuildAuditLog, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionViewAuditLogs); err != nil {
+		return
+	}
+
+	uri := EndpointGuildAuditLogs(guildID)
+
+	v := url.Values{}
+	if params != nil {
+		if params.UserID != "" {
+			v.Set("user_id", params.UserID)
+		}
+		if params.BeforeID != "" {
+			v.Set("before", params.BeforeID)
+		}
+		if params.AfterID != "" {
+			v.Set("after", params.AfterID)
+		}
+		if params.ActionType > 0 {
+			v.Set("action_type", strconv.Itoa(int(params.ActionType)))
+		}
+		if params.Limit > 0 {
+			v.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	if len(v) > 0 {
+		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildAuditLogs(guildID), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
 // GuildEmojis returns all emoji
 // guildID : The ID of a Guild.
 func (s *Session) GuildEmojis(guildID string) (emoji []*Emoji, err error) {
@@ -1364,7 +1625,11 @@ func (s *Session) GuildEmojis(guildID string) (emoji []*Emoji, err error) {
 // name    : The Name of the Emoji.
 // image   : The base64 encoded emoji image, has to be smaller than 256KB.
 // roles   : The roles for which this emoji will be whitelisted, can be nil.
-func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string) (emoji *Emoji, err error) {
+func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string, options ...RequestOption) (emoji *Emoji, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageEmojis); err != nil {
+		return
+	}
 
 	data := struct {
 		Name  string   `json:"name"`
@@ -1372,7 +1637,7 @@ func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string)
 		Roles []string `json:"roles,omitempty"`
 	}{name, image, roles}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildEmojis(guildID), data, EndpointGuildEmojis(guildID))
+	body, err := s.RequestWithBucketID("POST", EndpointGuildEmojis(guildID), data, EndpointGuildEmojis(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1386,14 +1651,18 @@ func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string)
 // emojiID : The ID of an Emoji.
 // name    : The Name of the Emoji.
 // roles   : The roles for which this emoji will be whitelisted, can be nil.
-func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string) (emoji *Emoji, err error) {
+func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string, options ...RequestOption) (emoji *Emoji, err error) {
+
+	if err = s.checkGuildPermissions(guildID, PermissionManageEmojis); err != nil {
+		return
+	}
 
 	data := struct {
 		Name  string   `json:"name"`
 		Roles []string `json:"roles,omitempty"`
 	}{name, roles}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildEmoji(guildID, emojiID), data, EndpointGuildEmojis(guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildEmoji(guildID, emojiID), data, EndpointGuildEmojis(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1405,9 +1674,13 @@ func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string)
 // GuildEmojiDelete deletes an Emoji.
 // guildID : The ID of a Guild.
 // emojiID : The ID of an Emoji.
-func (s *Session) GuildEmojiDelete(guildID, emojiID string) (err error) {
+func (s *Session) GuildEmojiDelete(guildID, emojiID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmojis(guildID))
+	if err = s.checkGuildPermissions(guildID, PermissionManageEmojis); err != nil {
+		return
+	}
+
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmojis(guildID), options...)
 	return
 }
 
@@ -1430,17 +1703,21 @@ func (s *Session) Channel(channelID string) (st *Channel, err error) {
 // ChannelEdit edits the given channel
 // channelID  : The ID of a Channel
 // name       : The new name to assign the channel.
-func (s *Session) ChannelEdit(channelID, name string) (*Channel, error) {
+func (s *Session) ChannelEdit(channelID, name string, options ...RequestOption) (*Channel, error) {
 	return s.ChannelEditComplex(channelID, &ChannelEdit{
 		Name: name,
-	})
+	}, options...)
 }
 
 // ChannelEditComplex edits an existing channel, replacing the parameters entirely with ChannelEdit struct
 // channelID  : The ID of a Channel
 // data          : The channel struct to send
-func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("PATCH", EndpointChannel(channelID), data, EndpointChannel(channelID))
+func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit, options ...RequestOption) (st *Channel, err error) {
+	if err = s.checkChannelPermissions(channelID, PermissionManageChannels); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointChannel(channelID), data, EndpointChannel(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1451,9 +1728,13 @@ func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *C
 
 // ChannelDelete deletes the given channel
 // channelID  : The ID of a Channel
-func (s *Session) ChannelDelete(channelID string) (st *Channel, err error) {
+func (s *Session) ChannelDelete(channelID string, options ...RequestOption) (st *Channel, err error) {
 
-	body, err := s.RequestWithBucketID("DELETE", EndpointChannel(channelID), nil, EndpointChannel(channelID))
+	if err = s.checkChannelPermissions(channelID, PermissionManageChannels); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("DELETE", EndpointChannel(channelID), nil, EndpointChannel(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1465,9 +1746,13 @@ func (s *Session) ChannelDelete(channelID string) (st *Channel, err error) {
 // ChannelTyping broadcasts to all members that authenticated user is typing in
 // the given channel.
 // channelID  : The ID of a Channel
-func (s *Session) ChannelTyping(channelID string) (err error) {
+func (s *Session) ChannelTyping(channelID string, options ...RequestOption) (err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionSendMessages); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("POST", EndpointChannelTyping(channelID), nil, EndpointChannelTyping(channelID))
+	_, err = s.RequestWithBucketID("POST", EndpointChannelTyping(channelID), nil, EndpointChannelTyping(channelID), options...)
 	return
 }
 
@@ -1480,6 +1765,10 @@ func (s *Session) ChannelTyping(channelID string) (err error) {
 // aroundID  : If provided all messages returned will be around given ID.
 func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID, aroundID string) (st []*Message, err error) {
 
+	if err = s.checkChannelPermissions(channelID, PermissionViewChannel|PermissionReadMessageHistory); err != nil {
+		return
+	}
+
 	uri := EndpointChannelMessages(channelID)
 
 	v := url.Values{}
@@ -1513,6 +1802,10 @@ func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID
 // messageID : the ID of a Message
 func (s *Session) ChannelMessage(channelID, messageID string) (st *Message, err error) {
 
+	if err = s.checkChannelPermissions(channelID, PermissionViewChannel|PermissionReadMessageHistory); err != nil {
+		return
+	}
+
 	response, err := s.RequestWithBucketID("GET", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""))
 	if err != nil {
 		return
@@ -1526,9 +1819,9 @@ func (s *Session) ChannelMessage(channelID, messageID string) (st *Message, err
 // channeld  : The ID of a Channel
 // messageID : the ID of a Message
 // lastToken : token returned by last ack
-func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string) (st *Ack, err error) {
+func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string, options ...RequestOption) (st *Ack, err error) {
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageAck(channelID, messageID), &Ack{Token: lastToken}, EndpointChannelMessageAck(channelID, ""))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageAck(channelID, messageID), &Ack{Token: lastToken}, EndpointChannelMessageAck(channelID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1540,10 +1833,10 @@ func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string) (st
 // ChannelMessageSend sends a message to the given channel.
 // channelID : The ID of a Channel.
 // content   : The message to send.
-func (s *Session) ChannelMessageSend(channelID string, content string) (*Message, error) {
+func (s *Session) ChannelMessageSend(channelID string, content string, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Content: content,
-	})
+	}, options...)
 }
 
 var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -1551,7 +1844,11 @@ var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 // ChannelMessageSendComplex sends a message to the given channel.
 // channelID : The ID of a Channel.
 // data      : The message struct to send.
-func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend) (st *Message, err error) {
+func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend, options ...RequestOption) (st *Message, err error) {
+	if err = s.checkChannelPermissions(channelID, data.requiredPermissions()); err != nil {
+		return
+	}
+
 	// TODO: Remove this when compatibility is not required.
 	if data.Embed != nil {
 		if data.Embeds == nil {
@@ -1582,14 +1879,12 @@ func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend)
 
 	var response []byte
 	if len(files) > 0 {
-		contentType, body, encodeErr := MultipartBodyWithJSON(data, files)
-		if encodeErr != nil {
-			return st, encodeErr
-		}
+		payload := *data
+		payload.Attachments = attachmentsWithFiles(data.Attachments, files)
 
-		response, err = s.request("POST", endpoint, contentType, body, endpoint, 0)
+		response, err = s.requestWithFiles("POST", endpoint, &payload, files, endpoint, options...)
 	} else {
-		response, err = s.RequestWithBucketID("POST", endpoint, data, endpoint)
+		response, err = s.RequestWithBucketID("POST", endpoint, data, endpoint, options...)
 	}
 	if err != nil {
 		return
@@ -1602,41 +1897,41 @@ func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend)
 // ChannelMessageSendTTS sends a message to the given channel with Text to Speech.
 // channelID : The ID of a Channel.
 // content   : The message to send.
-func (s *Session) ChannelMessageSendTTS(channelID string, content string) (*Message, error) {
+func (s *Session) ChannelMessageSendTTS(channelID string, content string, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Content: content,
 		TTS:     true,
-	})
+	}, options...)
 }
 
 // ChannelMessageSendEmbed sends a message to the given channel with embedded data.
 // channelID : The ID of a Channel.
 // embed     : The embed data to send.
-func (s *Session) ChannelMessageSendEmbed(channelID string, embed *MessageEmbed) (*Message, error) {
-	return s.ChannelMessageSendEmbeds(channelID, []*MessageEmbed{embed})
+func (s *Session) ChannelMessageSendEmbed(channelID string, embed *MessageEmbed, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageSendEmbeds(channelID, []*MessageEmbed{embed}, options...)
 }
 
 // ChannelMessageSendEmbeds sends a message to the given channel with multiple embedded data.
 // channelID : The ID of a Channel.
 // embeds    : The embeds data to send.
-func (s *Session) ChannelMessageSendEmbeds(channelID string, embeds []*MessageEmbed) (*Message, error) {
+func (s *Session) ChannelMessageSendEmbeds(channelID string, embeds []*MessageEmbed, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Embeds: embeds,
-	})
+	}, options...)
 }
 
 // ChannelMessageSendReply sends a message to the given channel with reference data.
 // channelID : The ID of a Channel.
 // content   : The message to send.
 // reference : The message reference to send.
-func (s *Session) ChannelMessageSendReply(channelID string, content string, reference *MessageReference) (*Message, error) {
+func (s *Session) ChannelMessageSendReply(channelID string, content string, reference *MessageReference, options ...RequestOption) (*Message, error) {
 	if reference == nil {
 		return nil, fmt.Errorf("reply attempted with nil message reference")
 	}
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Content:   content,
 		Reference: reference,
-	})
+	}, options...)
 }
 
 // ChannelMessageEdit edits an existing message, replacing it entirely with
@@ -1644,13 +1939,20 @@ func (s *Session) ChannelMessageSendReply(channelID string, content string, refe
 // channelID  : The ID of a Channel
 // messageID  : The ID of a Message
 // content    : The contents of the message
-func (s *Session) ChannelMessageEdit(channelID, messageID, content string) (*Message, error) {
-	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetContent(content))
+func (s *Session) ChannelMessageEdit(channelID, messageID, content string, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetContent(content), options...)
 }
 
 // ChannelMessageEditComplex edits an existing message, replacing it entirely with
-// the given MessageEdit struct
-func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err error) {
+// the given MessageEdit struct.
+// Files are added to the attachments of the message, if m.Attachments is set
+// only the listed attachments and the new files are kept. Files with a
+// Description require m.Attachments, see ErrEditFileDescription.
+func (s *Session) ChannelMessageEditComplex(m *MessageEdit, options ...RequestOption) (st *Message, err error) {
+	if err = s.checkChannelPermissions(m.Channel, m.requiredPermissions()); err != nil {
+		return
+	}
+
 	// TODO: Remove this when compatibility is not required.
 	if m.Embed != nil {
 		if m.Embeds == nil {
@@ -1666,7 +1968,21 @@ func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err er
 			embed.Type = "rich"
 		}
 	}
-	response, err := s.RequestWithBucketID("PATCH", EndpointChannelMessage(m.Channel, m.ID), m, EndpointChannelMessage(m.Channel, ""))
+
+	endpoint := EndpointChannelMessage(m.Channel, m.ID)
+
+	var response []byte
+	if len(m.Files) > 0 {
+		payload := *m
+		payload.Attachments, err = editAttachments(m.Attachments, m.Files)
+		if err != nil {
+			return
+		}
+
+		response, err = s.requestWithFiles("PATCH", endpoint, &payload, m.Files, EndpointChannelMessage(m.Channel, ""), options...)
+	} else {
+		response, err = s.RequestWithBucketID("PATCH", endpoint, m, EndpointChannelMessage(m.Channel, ""), options...)
+	}
 	if err != nil {
 		return
 	}
@@ -1679,22 +1995,22 @@ func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err er
 // channelID : The ID of a Channel
 // messageID : The ID of a Message
 // embed     : The embed data to send
-func (s *Session) ChannelMessageEditEmbed(channelID, messageID string, embed *MessageEmbed) (*Message, error) {
-	return s.ChannelMessageEditEmbeds(channelID, messageID, []*MessageEmbed{embed})
+func (s *Session) ChannelMessageEditEmbed(channelID, messageID string, embed *MessageEmbed, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageEditEmbeds(channelID, messageID, []*MessageEmbed{embed}, options...)
 }
 
 // ChannelMessageEditEmbeds edits an existing message with multiple embedded data.
 // channelID : The ID of a Channel
 // messageID : The ID of a Message
 // embeds    : The embeds data to send
-func (s *Session) ChannelMessageEditEmbeds(channelID, messageID string, embeds []*MessageEmbed) (*Message, error) {
-	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetEmbeds(embeds))
+func (s *Session) ChannelMessageEditEmbeds(channelID, messageID string, embeds []*MessageEmbed, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetEmbeds(embeds), options...)
 }
 
 // ChannelMessageDelete deletes a message from the Channel.
-func (s *Session) ChannelMessageDelete(channelID, messageID string) (err error) {
+func (s *Session) ChannelMessageDelete(channelID, messageID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""), options...)
 	return
 }
 
@@ -1703,14 +2019,18 @@ func (s *Session) ChannelMessageDelete(channelID, messageID string) (err error)
 // If the slice is empty do nothing.
 // channelID : The ID of the channel for the messages to delete.
 // messages  : The IDs of the messages to be deleted. A slice of string IDs. A maximum of 100 messages.
-func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string) (err error) {
+func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string, options ...RequestOption) (err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionManageMessages); err != nil {
+		return
+	}
 
 	if len(messages) == 0 {
 		return
 	}
 
 	if len(messages) == 1 {
-		err = s.ChannelMessageDelete(channelID, messages[0])
+		err = s.ChannelMessageDelete(channelID, messages[0], options...)
 		return
 	}
 
@@ -1722,25 +2042,33 @@ func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string)
 		Messages []string `json:"messages"`
 	}{messages}
 
-	_, err = s.RequestWithBucketID("POST", EndpointChannelMessagesBulkDelete(channelID), data, EndpointChannelMessagesBulkDelete(channelID))
+	_, err = s.RequestWithBucketID("POST", EndpointChannelMessagesBulkDelete(channelID), data, EndpointChannelMessagesBulkDelete(channelID), options...)
 	return
 }
 
 // ChannelMessagePin pins a message within a given channel.
 // channelID: The ID of a channel.
 // messageID: The ID of a message.
-func (s *Session) ChannelMessagePin(channelID, messageID string) (err error) {
+func (s *Session) ChannelMessagePin(channelID, messageID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("PUT", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""))
+	if err = s.checkChannelPermissions(channelID, PermissionManageMessages); err != nil {
+		return
+	}
+
+	_, err = s.RequestWithBucketID("PUT", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""), options...)
 	return
 }
 
 // ChannelMessageUnpin unpins a message within a given channel.
 // channelID: The ID of a channel.
 // messageID: The ID of a message.
-func (s *Session) ChannelMessageUnpin(channelID, messageID string) (err error) {
+func (s *Session) ChannelMessageUnpin(channelID, messageID string, options ...RequestOption) (err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionManageMessages); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""), options...)
 	return
 }
 
@@ -1749,6 +2077,10 @@ func (s *Session) ChannelMessageUnpin(channelID, messageID string) (err error) {
 // channelID : The ID of a Channel.
 func (s *Session) ChannelMessagesPinned(channelID string) (st []*Message, err error) {
 
+	if err = s.checkChannelPermissions(channelID, PermissionViewChannel|PermissionReadMessageHistory); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointChannelMessagesPins(channelID), nil, EndpointChannelMessagesPins(channelID))
 
 	if err != nil {
@@ -1763,8 +2095,8 @@ func (s *Session) ChannelMessagesPinned(channelID string) (st []*Message, err er
 // channelID : The ID of a Channel.
 // name: The name of the file.
 // io.Reader : A reader for the file contents.
-func (s *Session) ChannelFileSend(channelID, name string, r io.Reader) (*Message, error) {
-	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}})
+func (s *Session) ChannelFileSend(channelID, name string, r io.Reader, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}}, options...)
 }
 
 // ChannelFileSendWithMessage sends a file to the given channel with an message.
@@ -1773,14 +2105,18 @@ func (s *Session) ChannelFileSend(channelID, name string, r io.Reader) (*Message
 // content: Optional Message content.
 // name: The name of the file.
 // io.Reader : A reader for the file contents.
-func (s *Session) ChannelFileSendWithMessage(channelID, content string, name string, r io.Reader) (*Message, error) {
-	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}, Content: content})
+func (s *Session) ChannelFileSendWithMessage(channelID, content string, name string, r io.Reader, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}, Content: content}, options...)
 }
 
 // ChannelInvites returns an array of Invite structures for the given channel
 // channelID   : The ID of a Channel
 func (s *Session) ChannelInvites(channelID string) (st []*Invite, err error) {
 
+	if err = s.checkChannelPermissions(channelID, PermissionManageChannels); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointChannelInvites(channelID), nil, EndpointChannelInvites(channelID))
 	if err != nil {
 		return
@@ -1793,7 +2129,11 @@ func (s *Session) ChannelInvites(channelID string) (st []*Invite, err error) {
 // ChannelInviteCreate creates a new invite for the given channel.
 // channelID   : The ID of a Channel
 // i           : An Invite struct with the values MaxAge, MaxUses and Temporary defined.
-func (s *Session) ChannelInviteCreate(channelID string, i Invite) (st *Invite, err error) {
+func (s *Session) ChannelInviteCreate(channelID string, i Invite, options ...RequestOption) (st *Invite, err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionCreateInstantInvite); err != nil {
+		return
+	}
 
 	data := struct {
 		MaxAge    int  `json:"max_age"`
@@ -1802,7 +2142,7 @@ func (s *Session) ChannelInviteCreate(channelID string, i Invite) (st *Invite, e
 		Unique    bool `json:"unique"`
 	}{i.MaxAge, i.MaxUses, i.Temporary, i.Unique}
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelInvites(channelID), data, EndpointChannelInvites(channelID))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelInvites(channelID), data, EndpointChannelInvites(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1814,7 +2154,11 @@ func (s *Session) ChannelInviteCreate(channelID string, i Invite) (st *Invite, e
 // ChannelPermissionSet creates a Permission Override for the given channel.
 // NOTE: This func name may changed.  Using Set instead of Create because
 // you can both create a new override or update an override with this function.
-func (s *Session) ChannelPermissionSet(channelID, targetID string, targetType PermissionOverwriteType, allow, deny int64) (err error) {
+func (s *Session) ChannelPermissionSet(channelID, targetID string, targetType PermissionOverwriteType, allow, deny int64, options ...RequestOption) (err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionManageRoles); err != nil {
+		return
+	}
 
 	data := struct {
 		ID    string                  `json:"id"`
@@ -1823,15 +2167,19 @@ func (s *Session) ChannelPermissionSet(channelID, targetID string, targetType Pe
 		Deny  int64                   `json:"deny,string"`
 	}{targetID, targetType, allow, deny}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointChannelPermission(channelID, targetID), data, EndpointChannelPermission(channelID, ""))
+	_, err = s.RequestWithBucketID("PUT", EndpointChannelPermission(channelID, targetID), data, EndpointChannelPermission(channelID, ""), options...)
 	return
 }
 
 // ChannelPermissionDelete deletes a specific permission override for the given channel.
 // NOTE: Name of this func may change.
-func (s *Session) ChannelPermissionDelete(channelID, targetID string) (err error) {
+func (s *Session) ChannelPermissionDelete(channelID, targetID string, options ...RequestOption) (err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionManageRoles); err != nil {
+		return
+	}
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelPermission(channelID, targetID), nil, EndpointChannelPermission(channelID, ""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelPermission(channelID, targetID), nil, EndpointChannelPermission(channelID, ""), options...)
 	return
 }
 
@@ -1839,11 +2187,11 @@ func (s *Session) ChannelPermissionDelete(channelID, targetID string) (err error
 // of the channel
 // channelID   : The ID of a Channel
 // messageID   : The ID of a Message
-func (s *Session) ChannelMessageCrosspost(channelID, messageID string) (st *Message, err error) {
+func (s *Session) ChannelMessageCrosspost(channelID, messageID string, options ...RequestOption) (st *Message, err error) {
 
 	endpoint := EndpointChannelMessageCrosspost(channelID, messageID)
 
-	body, err := s.RequestWithBucketID("POST", endpoint, nil, endpoint)
+	body, err := s.RequestWithBucketID("POST", endpoint, nil, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -1855,7 +2203,11 @@ func (s *Session) ChannelMessageCrosspost(channelID, messageID string) (st *Mess
 // ChannelNewsFollow follows a news channel in the targetID
 // channelID   : The ID of a News Channel
 // targetID    : The ID of a Channel where the News Channel should post to
-func (s *Session) ChannelNewsFollow(channelID, targetID string) (st *ChannelFollow, err error) {
+func (s *Session) ChannelNewsFollow(channelID, targetID string, options ...RequestOption) (st *ChannelFollow, err error) {
+
+	if err = s.checkChannelPermissions(targetID, PermissionManageWebhooks); err != nil {
+		return
+	}
 
 	endpoint := EndpointChannelFollow(channelID)
 
@@ -1863,7 +2215,7 @@ func (s *Session) ChannelNewsFollow(channelID, targetID string) (st *ChannelFoll
 		WebhookChannelID string `json:"webhook_channel_id"`
 	}{targetID}
 
-	body, err := s.RequestWithBucketID("POST", endpoint, data, endpoint)
+	body, err := s.RequestWithBucketID("POST", endpoint, data, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -1904,9 +2256,9 @@ func (s *Session) InviteWithCounts(inviteID string) (st *Invite, err error) {
 
 // InviteDelete deletes an existing invite
 // inviteID   : the code of an invite
-func (s *Session) InviteDelete(inviteID string) (st *Invite, err error) {
+func (s *Session) InviteDelete(inviteID string, options ...RequestOption) (st *Invite, err error) {
 
-	body, err := s.RequestWithBucketID("DELETE", EndpointInvite(inviteID), nil, EndpointInvite(""))
+	body, err := s.RequestWithBucketID("DELETE", EndpointInvite(inviteID), nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -1917,9 +2269,9 @@ func (s *Session) InviteDelete(inviteID string) (st *Invite, err error) {
 
 // InviteAccept accepts an Invite to a Guild or Channel
 // inviteID : The invite code
-func (s *Session) InviteAccept(inviteID string) (st *Invite, err error) {
+func (s *Session) InviteAccept(inviteID string, options ...RequestOption) (st *Invite, err error) {
 
-	body, err := s.RequestWithBucketID("POST", EndpointInvite(inviteID), nil, EndpointInvite(""))
+	body, err := s.RequestWithBucketID("POST", EndpointInvite(inviteID), nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -2016,14 +2368,18 @@ func (s *Session) GatewayBot() (st *GatewayBotResponse, err error) {
 // channelID: The ID of a Channel.
 // name     : The name of the webhook.
 // avatar   : The avatar of the webhook.
-func (s *Session) WebhookCreate(channelID, name, avatar string) (st *Webhook, err error) {
+func (s *Session) WebhookCreate(channelID, name, avatar string, options ...RequestOption) (st *Webhook, err error) {
+
+	if err = s.checkChannelPermissions(channelID, PermissionManageWebhooks); err != nil {
+		return
+	}
 
 	data := struct {
 		Name   string `json:"name"`
 		Avatar string `json:"avatar,omitempty"`
 	}{name, avatar}
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelWebhooks(channelID), data, EndpointChannelWebhooks(channelID))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelWebhooks(channelID), data, EndpointChannelWebhooks(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -2037,6 +2393,10 @@ func (s *Session) WebhookCreate(channelID, name, avatar string) (st *Webhook, er
 // channelID: The ID of a channel.
 func (s *Session) ChannelWebhooks(channelID string) (st []*Webhook, err error) {
 
+	if err = s.checkChannelPermissions(channelID, PermissionManageWebhooks); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointChannelWebhooks(channelID), nil, EndpointChannelWebhooks(channelID))
 	if err != nil {
 		return
@@ -2051,6 +2411,10 @@ func (s *Session) ChannelWebhooks(channelID string) (st []*Webhook, err error) {
 // guildID: The ID of a Guild.
 func (s *Session) GuildWebhooks(guildID string) (st []*Webhook, err error) {
 
+	if err = s.checkGuildPermissions(guildID, PermissionManageWebhooks); err != nil {
+		return
+	}
+
 	body, err := s.RequestWithBucketID("GET", EndpointGuildWebhooks(guildID), nil, EndpointGuildWebhooks(guildID))
 	if err != nil {
 		return
@@ -2094,7 +2458,7 @@ func (s *Session) WebhookWithToken(webhookID, token string) (st *Webhook, err er
 // webhookID: The ID of a webhook.
 // name     : The name of the webhook.
 // avatar   : The avatar of the webhook.
-func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *Role, err error) {
+func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string, options ...RequestOption) (st *Role, err error) {
 
 	data := struct {
 		Name      string `json:"name,omitempty"`
@@ -2102,7 +2466,7 @@ func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *Ro
 		ChannelID string `json:"channel_id,omitempty"`
 	}{name, avatar, channelID}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointWebhook(webhookID), data, EndpointWebhooks)
+	body, err := s.RequestWithBucketID("PATCH", EndpointWebhook(webhookID), data, EndpointWebhooks, options...)
 	if err != nil {
 		return
 	}
@@ -2117,14 +2481,14 @@ func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *Ro
 // token    : The auth token for the webhook.
 // name     : The name of the webhook.
 // avatar   : The avatar of the webhook.
-func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string) (st *Role, err error) {
+func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string, options ...RequestOption) (st *Role, err error) {
 
 	data := struct {
 		Name   string `json:"name,omitempty"`
 		Avatar string `json:"avatar,omitempty"`
 	}{name, avatar}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointWebhookToken(webhookID, token), data, EndpointWebhookToken("", ""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointWebhookToken(webhookID, token), data, EndpointWebhookToken("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2136,9 +2500,9 @@ func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string) (s
 
 // WebhookDelete deletes a webhook for a given ID
 // webhookID: The ID of a webhook.
-func (s *Session) WebhookDelete(webhookID string) (err error) {
+func (s *Session) WebhookDelete(webhookID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointWebhook(webhookID), nil, EndpointWebhooks)
+	_, err = s.RequestWithBucketID("DELETE", EndpointWebhook(webhookID), nil, EndpointWebhooks, options...)
 
 	return
 }
@@ -2146,9 +2510,9 @@ func (s *Session) WebhookDelete(webhookID string) (err error) {
 // WebhookDeleteWithToken deletes a webhook for a given ID with an auth token.
 // webhookID: The ID of a webhook.
 // token    : The auth token for the webhook.
-func (s *Session) WebhookDeleteWithToken(webhookID, token string) (st *Webhook, err error) {
+func (s *Session) WebhookDeleteWithToken(webhookID, token string, options ...RequestOption) (st *Webhook, err error) {
 
-	body, err := s.RequestWithBucketID("DELETE", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""))
+	body, err := s.RequestWithBucketID("DELETE", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2162,7 +2526,7 @@ func (s *Session) WebhookDeleteWithToken(webhookID, token string) (st *Webhook,
 // webhookID: The ID of a webhook.
 // token    : The auth token for the webhook
 // wait     : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
-func (s *Session) WebhookExecute(webhookID, token string, wait bool, data *WebhookParams) (st *Message, err error) {
+func (s *Session) WebhookExecute(webhookID, token string, wait bool, data *WebhookParams, options ...RequestOption) (st *Message, err error) {
 	uri := EndpointWebhookToken(webhookID, token)
 
 	if wait {
@@ -2171,14 +2535,12 @@ func (s *Session) WebhookExecute(webhookID, token string, wait bool, data *Webho
 
 	var response []byte
 	if len(data.Files) > 0 {
-		contentType, body, encodeErr := MultipartBodyWithJSON(data, data.Files)
-		if encodeErr != nil {
-			return st, encodeErr
-		}
+		payload := *data
+		payload.Attachments = attachmentsWithFiles(data.Attachments, data.Files)
 
-		response, err = s.request("POST", uri, contentType, body, uri, 0)
+		response, err = s.requestWithFiles("POST", uri, &payload, data.Files, uri, options...)
 	} else {
-		response, err = s.RequestWithBucketID("POST", uri, data, uri)
+		response, err = s.RequestWithBucketID("POST", uri, data, uri, options...)
 	}
 	if !wait || err != nil {
 		return
@@ -2209,22 +2571,23 @@ func (s *Session) WebhookMessage(webhookID, token, messageID string) (message *M
 // webhookID : The ID of a webhook
 // token     : The auth token for the webhook
 // messageID : The ID of message to edit
-func (s *Session) WebhookMessageEdit(webhookID, token, messageID string, data *WebhookEdit) (st *Message, err error) {
+func (s *Session) WebhookMessageEdit(webhookID, token, messageID string, data *WebhookEdit, options ...RequestOption) (st *Message, err error) {
 	uri := EndpointWebhookMessage(webhookID, token, messageID)
 
 	var response []byte
 	if len(data.Files) > 0 {
-		contentType, body, err := MultipartBodyWithJSON(data, data.Files)
+		payload := *data
+		payload.Attachments, err = editAttachments(data.Attachments, data.Files)
 		if err != nil {
 			return nil, err
 		}
 
-		response, err = s.request("PATCH", uri, contentType, body, uri, 0)
+		response, err = s.requestWithFiles("PATCH", uri, &payload, data.Files, uri, options...)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		response, err = s.RequestWithBucketID("PATCH", uri, data, EndpointWebhookToken("", ""))
+		response, err = s.RequestWithBucketID("PATCH", uri, data, EndpointWebhookToken("", ""), options...)
 
 		if err != nil {
 			return nil, err
@@ -2239,10 +2602,10 @@ func (s *Session) WebhookMessageEdit(webhookID, token, messageID string, data *W
 // webhookID : The ID of a webhook
 // token     : The auth token for the webhook
 // messageID : The ID of a message to edit
-func (s *Session) WebhookMessageDelete(webhookID, token, messageID string) (err error) {
+func (s *Session) WebhookMessageDelete(webhookID, token, messageID string, options ...RequestOption) (err error) {
 	uri := EndpointWebhookMessage(webhookID, token, messageID)
 
-	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointWebhookToken("", ""))
+	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointWebhookToken("", ""), options...)
 	return
 }
 
@@ -2250,11 +2613,15 @@ func (s *Session) WebhookMessageDelete(webhookID, token, messageID string) (err
 // channelID : The channel ID.
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
-func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error {
+func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string, options ...RequestOption) error {
+
+	if err := s.checkChannelPermissions(channelID, PermissionAddReactions|PermissionReadMessageHistory); err != nil {
+		return err
+	}
 
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID, "@me"), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID, "@me"), nil, EndpointMessageReaction(channelID, "", "", ""), options...)
 
 	return err
 }
@@ -2264,11 +2631,15 @@ func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
 // userID	 : @me or ID of the user to delete the reaction for.
-func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID string) error {
+func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID string, options ...RequestOption) error {
+
+	if err := s.checkChannelPermissions(channelID, reactionRemovePermission(userID)); err != nil {
+		return err
+	}
 
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID, userID), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID, userID), nil, EndpointMessageReaction(channelID, "", "", ""), options...)
 
 	return err
 }
@@ -2276,9 +2647,13 @@ func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID st
 // MessageReactionsRemoveAll deletes all reactions from a message
 // channelID : The channel ID
 // messageID : The message ID.
-func (s *Session) MessageReactionsRemoveAll(channelID, messageID string) error {
+func (s *Session) MessageReactionsRemoveAll(channelID, messageID string, options ...RequestOption) error {
+
+	if err := s.checkChannelPermissions(channelID, PermissionManageMessages); err != nil {
+		return err
+	}
 
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactionsAll(channelID, messageID), nil, EndpointMessageReactionsAll(channelID, messageID))
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactionsAll(channelID, messageID), nil, EndpointMessageReactionsAll(channelID, messageID), options...)
 
 	return err
 }
@@ -2287,11 +2662,15 @@ func (s *Session) MessageReactionsRemoveAll(channelID, messageID string) error {
 // channelID : The channel ID
 // messageID : The message ID
 // emojiID   : The emoji ID
-func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID string) error {
+func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID string, options ...RequestOption) error {
+
+	if err := s.checkChannelPermissions(channelID, PermissionManageMessages); err != nil {
+		return err
+	}
 
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactions(channelID, messageID, emojiID), nil, EndpointMessageReactions(channelID, messageID, emojiID))
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactions(channelID, messageID, emojiID), nil, EndpointMessageReactions(channelID, messageID, emojiID), options...)
 
 	return err
 }
@@ -2304,6 +2683,10 @@ func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID stri
 // beforeID  : If provided all reactions returned will be before given ID.
 // afterID   : If provided all reactions returned will be after given ID.
 func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit int, beforeID, afterID string) (st []*User, err error) {
+	if err = s.checkChannelPermissions(channelID, PermissionViewChannel|PermissionReadMessageHistory); err != nil {
+		return
+	}
+
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
 	uri := EndpointMessageReactions(channelID, messageID, emojiID)
@@ -2339,12 +2722,12 @@ func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit i
 // ------------------------------------------------------------------------------------------------
 
 // UserNoteSet sets the note for a specific user.
-func (s *Session) UserNoteSet(userID string, message string) (err error) {
+func (s *Session) UserNoteSet(userID string, message string, options ...RequestOption) (err error) {
 	data := struct {
 		Note string `json:"note"`
 	}{message}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointUserNotes(userID), data, EndpointUserNotes(""))
+	_, err = s.RequestWithBucketID("PUT", EndpointUserNotes(userID), data, EndpointUserNotes(""), options...)
 	return
 }
 
@@ -2365,40 +2748,40 @@ func (s *Session) RelationshipsGet() (r []*Relationship, err error) {
 
 // relationshipCreate creates a new relationship. (I.e. send or accept a friend request, block a user.)
 // relationshipType : 1 = friend, 2 = blocked, 3 = incoming friend req, 4 = sent friend req
-func (s *Session) relationshipCreate(userID string, relationshipType int) (err error) {
+func (s *Session) relationshipCreate(userID string, relationshipType int, options ...RequestOption) (err error) {
 	data := struct {
 		Type int `json:"type"`
 	}{relationshipType}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointRelationship(userID), data, EndpointRelationships())
+	_, err = s.RequestWithBucketID("PUT", EndpointRelationship(userID), data, EndpointRelationships(), options...)
 	return
 }
 
 // RelationshipFriendRequestSend sends a friend request to a user.
 // userID: ID of the user.
-func (s *Session) RelationshipFriendRequestSend(userID string) (err error) {
-	err = s.relationshipCreate(userID, 4)
+func (s *Session) RelationshipFriendRequestSend(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 4, options...)
 	return
 }
 
 // RelationshipFriendRequestAccept accepts a friend request from a user.
 // userID: ID of the user.
-func (s *Session) RelationshipFriendRequestAccept(userID string) (err error) {
-	err = s.relationshipCreate(userID, 1)
+func (s *Session) RelationshipFriendRequestAccept(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 1, options...)
 	return
 }
 
 // RelationshipUserBlock blocks a user.
 // userID: ID of the user.
-func (s *Session) RelationshipUserBlock(userID string) (err error) {
-	err = s.relationshipCreate(userID, 2)
+func (s *Session) RelationshipUserBlock(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 2, options...)
 	return
 }
 
 // RelationshipDelete removes the relationship with a user.
 // userID: ID of the user.
-func (s *Session) RelationshipDelete(userID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointRelationship(userID), nil, EndpointRelationships())
+func (s *Session) RelationshipDelete(userID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointRelationship(userID), nil, EndpointRelationships(), options...)
 	return
 }
 
@@ -2422,13 +2805,13 @@ func (s *Session) RelationshipsMutualGet(userID string) (mf []*User, err error)
 // appID       : The application ID.
 // guildID     : Guild ID to create guild-specific application command. If empty - creates global application command.
 // cmd         : New application command data.
-func (s *Session) ApplicationCommandCreate(appID string, guildID string, cmd *ApplicationCommand) (ccmd *ApplicationCommand, err error) {
+func (s *Session) ApplicationCommandCreate(appID string, guildID string, cmd *ApplicationCommand, options ...RequestOption) (ccmd *ApplicationCommand, err error) {
 	endpoint := EndpointApplicationGlobalCommands(appID)
 	if guildID != "" {
 		endpoint = EndpointApplicationGuildCommands(appID, guildID)
 	}
 
-	body, err := s.RequestWithBucketID("POST", endpoint, *cmd, endpoint)
+	body, err := s.RequestWithBucketID("POST", endpoint, *cmd, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -2443,13 +2826,13 @@ func (s *Session) ApplicationCommandCreate(appID string, guildID string, cmd *Ap
 // cmdID       : Application command ID to edit.
 // guildID     : Guild ID to edit guild-specific application command. If empty - edits global application command.
 // cmd         : Updated application command data.
-func (s *Session) ApplicationCommandEdit(appID, guildID, cmdID string, cmd *ApplicationCommand) (updated *ApplicationCommand, err error) {
+func (s *Session) ApplicationCommandEdit(appID, guildID, cmdID string, cmd *ApplicationCommand, options ...RequestOption) (updated *ApplicationCommand, err error) {
 	endpoint := EndpointApplicationGlobalCommand(appID, cmdID)
 	if guildID != "" {
 		endpoint = EndpointApplicationGuildCommand(appID, guildID, cmdID)
 	}
 
-	body, err := s.RequestWithBucketID("PATCH", endpoint, *cmd, endpoint)
+	body, err := s.RequestWithBucketID("PATCH", endpoint, *cmd, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -2462,13 +2845,13 @@ func (s *Session) ApplicationCommandEdit(appID, guildID, cmdID string, cmd *Appl
 // ApplicationCommandBulkOverwrite Creates commands overwriting existing commands. Returns a list of commands.
 // appID    : The application ID.
 // commands : The commands to create.
-func (s *Session) ApplicationCommandBulkOverwrite(appID string, guildID string, commands []*ApplicationCommand) (createdCommands []*ApplicationCommand, err error) {
+func (s *Session) ApplicationCommandBulkOverwrite(appID string, guildID string, commands []*ApplicationCommand, options ...RequestOption) (createdCommands []*ApplicationCommand, err error) {
 	endpoint := EndpointApplicationGlobalCommands(appID)
 	if guildID != "" {
 		endpoint = EndpointApplicationGuildCommands(appID, guildID)
 	}
 
-	body, err := s.RequestWithBucketID("PUT", endpoint, commands, endpoint)
+	body, err := s.RequestWithBucketID("PUT", endpoint, commands, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -2482,13 +2865,13 @@ func (s *Session) ApplicationCommandBulkOverwrite(appID string, guildID string,
 // appID       : The application ID.
 // cmdID       : Application command ID to delete.
 // guildID     : Guild ID to delete guild-specific application command. If empty - deletes global application command.
-func (s *Session) ApplicationCommandDelete(appID, guildID, cmdID string) error {
+func (s *Session) ApplicationCommandDelete(appID, guildID, cmdID string, options ...RequestOption) error {
 	endpoint := EndpointApplicationGlobalCommand(appID, cmdID)
 	if guildID != "" {
 		endpoint = EndpointApplicationGuildCommand(appID, guildID, cmdID)
 	}
 
-	_, err := s.RequestWithBucketID("DELETE", endpoint, nil, endpoint)
+	_, err := s.RequestWithBucketID("DELETE", endpoint, nil, endpoint, options...)
 
 	return err
 }
@@ -2532,22 +2915,74 @@ func (s *Session) ApplicationCommands(appID, guildID string) (cmd []*Application
 	return
 }
 
+// GuildApplicationCommandsPermissions returns the permissions of all
+// application commands in a guild.
+// appID     : The application ID.
+// guildID   : The guild ID.
+func (s *Session) GuildApplicationCommandsPermissions(appID, guildID string) (permissions []*GuildApplicationCommandPermissions, err error) {
+	endpoint := EndpointApplicationCommandsGuildPermissions(appID, guildID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &permissions)
+	return
+}
+
+// ApplicationCommandPermissions returns the permissions of an application
+// command in a guild.
+// appID     : The application ID.
+// guildID   : The guild ID.
+// cmdID     : The command ID, or the application ID for the permissions of all commands.
+func (s *Session) ApplicationCommandPermissions(appID, guildID, cmdID string) (permissions *GuildApplicationCommandPermissions, err error) {
+	endpoint := EndpointApplicationCommandPermissions(appID, guildID, cmdID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &permissions)
+	return
+}
+
+// ApplicationCommandPermissionsEdit replaces the permissions of an
+// application command in a guild. Discord only allows this with a Bearer
+// token of a guild admin with the applications.commands.permissions.update
+// scope, so the session token must be such a token.
+// appID       : The application ID.
+// guildID     : The guild ID.
+// cmdID       : The command ID, or the application ID for the permissions of all commands.
+// permissions : The new permissions of the command.
+func (s *Session) ApplicationCommandPermissionsEdit(appID, guildID, cmdID string, permissions *ApplicationCommandPermissionsList, options ...RequestOption) (st *GuildApplicationCommandPermissions, err error) {
+	endpoint := EndpointApplicationCommandPermissions(appID, guildID, cmdID)
+
+	body, err := s.RequestWithBucketID("PUT", endpoint, permissions, endpoint, options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
 // InteractionRespond creates the response to an interaction.
 // appID       : The application ID.
 // interaction : Interaction instance.
 // resp        : Response message data.
-func (s *Session) InteractionRespond(interaction *Interaction, resp *InteractionResponse) (err error) {
+func (s *Session) InteractionRespond(interaction *Interaction, resp *InteractionResponse, options ...RequestOption) (err error) {
 	endpoint := EndpointInteractionResponse(interaction.ID, interaction.Token)
 
 	if resp.Data != nil && len(resp.Data.Files) > 0 {
-		contentType, body, err := MultipartBodyWithJSON(resp, resp.Data.Files)
-		if err != nil {
-			return err
-		}
+		data := *resp.Data
+		data.Attachments = attachmentsWithFiles(resp.Data.Attachments, resp.Data.Files)
+		payload := InteractionResponse{Type: resp.Type, Data: &data}
 
-		_, err = s.request("POST", endpoint, contentType, body, endpoint, 0)
+		_, err = s.requestWithFiles("POST", endpoint, &payload, resp.Data.Files, endpoint, options...)
 	} else {
-		_, err = s.RequestWithBucketID("POST", endpoint, *resp, endpoint)
+		_, err = s.RequestWithBucketID("POST", endpoint, *resp, endpoint, options...)
 	}
 	return err
 }
@@ -2563,17 +2998,17 @@ func (s *Session) InteractionResponse(appID string, interaction *Interaction) (*
 // appID       : The application ID.
 // interaction : Interaction instance.
 // newresp     : Updated response message data.
-func (s *Session) InteractionResponseEdit(appID string, interaction *Interaction, newresp *WebhookEdit) (*Message, error) {
-	return s.WebhookMessageEdit(appID, interaction.Token, "@original", newresp)
+func (s *Session) InteractionResponseEdit(appID string, interaction *Interaction, newresp *WebhookEdit, options ...RequestOption) (*Message, error) {
+	return s.WebhookMessageEdit(appID, interaction.Token, "@original", newresp, options...)
 }
 
 // InteractionResponseDelete deletes the response to an interaction.
 // appID       : The application ID.
 // interaction : Interaction instance.
-func (s *Session) InteractionResponseDelete(appID string, interaction *Interaction) error {
+func (s *Session) InteractionResponseDelete(appID string, interaction *Interaction, options ...RequestOption) error {
 	endpoint := EndpointInteractionResponseActions(appID, interaction.Token)
 
-	_, err := s.RequestWithBucketID("DELETE", endpoint, nil, endpoint)
+	_, err := s.RequestWithBucketID("DELETE", endpoint, nil, endpoint, options...)
 
 	return err
 }
@@ -2583,8 +3018,8 @@ func (s *Session) InteractionResponseDelete(appID string, interaction *Interacti
 // interaction : Interaction instance.
 // wait        : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
 // data        : Data of the message to send.
-func (s *Session) FollowupMessageCreate(appID string, interaction *Interaction, wait bool, data *WebhookParams) (*Message, error) {
-	return s.WebhookExecute(appID, interaction.Token, wait, data)
+func (s *Session) FollowupMessageCreate(appID string, interaction *Interaction, wait bool, data *WebhookParams, options ...RequestOption) (*Message, error) {
+	return s.WebhookExecute(appID, interaction.Token, wait, data, options...)
 }
 
 // FollowupMessageEdit edits a followup message of an interaction.
@@ -2592,14 +3027,14 @@ func (s *Session) FollowupMessageCreate(appID string, interaction *Interaction,
 // interaction : Interaction instance.
 // messageID   : The followup message ID.
 // data        : Data to update the message
-func (s *Session) FollowupMessageEdit(appID string, interaction *Interaction, messageID string, data *WebhookEdit) (*Message, error) {
-	return s.WebhookMessageEdit(appID, interaction.Token, messageID, data)
+func (s *Session) FollowupMessageEdit(appID string, interaction *Interaction, messageID string, data *WebhookEdit, options ...RequestOption) (*Message, error) {
+	return s.WebhookMessageEdit(appID, interaction.Token, messageID, data, options...)
 }
 
 // FollowupMessageDelete deletes a followup message of an interaction.
 // appID       : The application ID.
 // interaction : Interaction instance.
 // messageID   : The followup message ID.
-func (s *Session) FollowupMessageDelete(appID string, interaction *Interaction, messageID string) error {
-	return s.WebhookMessageDelete(appID, interaction.Token, messageID)
+func (s *Session) FollowupMessageDelete(appID string, interaction *Interaction, messageID string, options ...RequestOption) error {
+	return s.WebhookMessageDelete(appID, interaction.Token, messageID, options...)
 }
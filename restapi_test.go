@@ -2,6 +2,10 @@ package discordgo
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -293,3 +297,52 @@ func Test_unmarshal(t *testing.T) {
 		t.Errorf("Unexpected error type: %T", err)
 	}
 }
+
+func TestWithAuditLogReason(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Audit-Log-Reason")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s, _ := New("Bot token")
+	_, err := s.RequestWithBucketID("DELETE", server.URL, nil, "audit", WithAuditLogReason("spam & ads/äö"))
+	if err != nil {
+		t.Fatalf("request returned error: %v", err)
+	}
+	if want := "spam%20&%20ads%2F%C3%A4%C3%B6"; header != want {
+		t.Errorf("X-Audit-Log-Reason: got %q, want %q", header, want)
+	}
+
+	header = ""
+	_, err = s.RequestWithBucketID("DELETE", server.URL, nil, "audit", WithAuditLogReason(strings.Repeat("a", AuditLogReasonLimit+1)))
+	if err != ErrAuditLogReasonTooLong {
+		t.Errorf("request with long reason: got error %v, want %v", err, ErrAuditLogReasonTooLong)
+	}
+	if header != "" {
+		t.Errorf("request with long reason was sent")
+	}
+}
+
+// testTransport sends all requests to a test server.
+type testTransport struct {
+	url *url.URL
+}
+
+func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.url.Scheme
+	req.URL.Host = t.url.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestSession returns a Session which sends its REST requests to
+// handler, and a func to stop the test server.
+func newTestSession(handler http.HandlerFunc) (*Session, func()) {
+	server := httptest.NewServer(handler)
+
+	u, _ := url.Parse(server.URL)
+	s, _ := New("Bot token")
+	s.Client = &http.Client{Transport: &testTransport{url: u}}
+	return s, server.Close
+}
@@ -1,5 +1,10 @@
 package discordgo
 
+import (
+	"net/url"
+	"strconv"
+)
+
 // GuildScheduledEvents returns an array of GuildScheduledEvent for a guild
 // guildID   : The ID of a Guild
 func (s *Session) GuildScheduledEvents(guildID string) (st []*GuildScheduledEvent, err error) {
@@ -28,8 +33,12 @@ func (s *Session) GuildScheduledEvent(guildID, eventID string) (st *GuildSchedul
 // GuildScheduledEvents returns an array of GuildScheduledEvent for a guild
 // guildID   : The ID of a Guild
 // eventID   : The ID of the event
-func (s *Session) GuildScheduledEventCreate(guildID string, event *GuildScheduledEvent) (st *GuildScheduledEvent, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointGuildScheduledEvents(guildID), event, EndpointGuildScheduledEvents(guildID))
+func (s *Session) GuildScheduledEventCreate(guildID string, event *GuildScheduledEvent, options ...RequestOption) (st *GuildScheduledEvent, err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionManageEvents); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("POST", EndpointGuildScheduledEvents(guildID), event, EndpointGuildScheduledEvents(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -41,8 +50,12 @@ func (s *Session) GuildScheduledEventCreate(guildID string, event *GuildSchedule
 // GuildScheduledEvents returns an array of GuildScheduledEvent for a guild
 // guildID   : The ID of a Guild
 // eventID   : The ID of the event
-func (s *Session) GuildScheduledEventUpdate(guildID, eventID string, event *GuildScheduledEvent) (st *GuildScheduledEvent, err error) {
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildScheduledEvent(guildID, eventID), event, EndpointGuildScheduledEvent(guildID, eventID))
+func (s *Session) GuildScheduledEventUpdate(guildID, eventID string, event *GuildScheduledEvent, options ...RequestOption) (st *GuildScheduledEvent, err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionManageEvents); err != nil {
+		return
+	}
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildScheduledEvent(guildID, eventID), event, EndpointGuildScheduledEvent(guildID, eventID), options...)
 	if err != nil {
 		return
 	}
@@ -54,8 +67,12 @@ func (s *Session) GuildScheduledEventUpdate(guildID, eventID string, event *Guil
 // GuildScheduledEvents returns an array of GuildScheduledEvent for a guild
 // guildID   : The ID of a Guild
 // eventID   : The ID of the event
-func (s *Session) GuildScheduledEventDelete(guildID, eventID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildScheduledEvent(guildID, eventID), nil, EndpointGuildScheduledEvent(guildID, eventID))
+func (s *Session) GuildScheduledEventDelete(guildID, eventID string, options ...RequestOption) (err error) {
+	if err = s.checkGuildPermissions(guildID, PermissionManageEvents); err != nil {
+		return
+	}
+
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildScheduledEvent(guildID, eventID), nil, EndpointGuildScheduledEvent(guildID, eventID), options...)
 	return
 }
 
@@ -63,7 +80,34 @@ func (s *Session) GuildScheduledEventDelete(guildID, eventID string) (err error)
 // guildID   : The ID of a Guild
 // eventID   : The ID of the event
 func (s *Session) GuildScheduledEventUsers(guildID, eventID string) (st []*GuildScheduledEventUser, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildScheduledEventUsers(guildID, eventID), nil, EndpointGuildScheduledEventUsers(guildID, eventID))
+	return s.guildScheduledEventUsers(guildID, eventID, 0, "", "")
+}
+
+// guildScheduledEventUsers returns a page of the users subscribed to a
+// scheduled event. Use GuildScheduledEventUsersIterator to walk all users.
+// guildID   : The ID of a Guild
+// eventID   : The ID of the event
+// limit     : The number of users to return (max 100)
+// beforeID  : If provided all users returned will be before given ID.
+// afterID   : If provided all users returned will be after given ID.
+func (s *Session) guildScheduledEventUsers(guildID, eventID string, limit int, beforeID, afterID string) (st []*GuildScheduledEventUser, err error) {
+	uri := EndpointGuildScheduledEventUsers(guildID, eventID)
+
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if afterID != "" {
+		v.Set("after", afterID)
+	}
+	if beforeID != "" {
+		v.Set("before", beforeID)
+	}
+	if len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildScheduledEventUsers(guildID, eventID))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,152 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the Snowflake type and helpers to work with
+// Snowflake IDs.
+
+package discordgo
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiscordEpoch is the first millisecond of 2015, the epoch of the
+// timestamps of Snowflake IDs, in milliseconds since the Unix epoch.
+const DiscordEpoch = 1420070400000
+
+// A Snowflake is a unique ID used by Discord, which contains the time it
+// was created at. It is marshaled to JSON as a string, like Discord does,
+// and can be unmarshaled from a JSON string or number.
+// https://discord.com/developers/docs/reference#snowflakes
+//
+// IDs are strings in the rest of the package, use ParseSnowflake and
+// String to convert between both.
+type Snowflake uint64
+
+// ParseSnowflake parses a Snowflake ID.
+func ParseSnowflake(id string) (Snowflake, error) {
+	i, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return Snowflake(i), nil
+}
+
+// NewSnowflake returns the lowest Snowflake ID created at t, to compare
+// IDs with t or to page through lists of items created before or after t.
+// Times before DiscordEpoch return 0.
+func NewSnowflake(t time.Time) Snowflake {
+	ms := t.UnixNano()/int64(time.Millisecond) - DiscordEpoch
+	if ms < 0 {
+		return 0
+	}
+	return Snowflake(ms) << 22
+}
+
+// IsSnowflake reports whether id is a valid Snowflake ID: a non-zero
+// decimal number which fits in 64 bits.
+func IsSnowflake(id string) bool {
+	s, err := ParseSnowflake(id)
+	return err == nil && s != 0
+}
+
+// CompareSnowflakes compares the Snowflake IDs a and b as numbers. It
+// returns -1 if a is older than b, 1 if a is newer than b and 0 if they
+// are the same. a and b do not need to be valid Snowflake IDs.
+func CompareSnowflakes(a, b string) int {
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// String returns the Snowflake ID as a decimal string.
+func (s Snowflake) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Time returns the time the Snowflake ID was created at.
+// It is useful to e.g. check whether a message is younger than 14 days,
+// and can be bulk deleted:
+//
+//	time.Since(id.Time()) < 14*24*time.Hour
+func (s Snowflake) Time() time.Time {
+	ms := int64(s>>22) + DiscordEpoch
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// Worker returns the ID of the internal worker which created the
+// Snowflake ID.
+func (s Snowflake) Worker() uint8 {
+	return uint8(s>>17) & 0x1F
+}
+
+// Process returns the ID of the internal process which created the
+// Snowflake ID.
+func (s Snowflake) Process() uint8 {
+	return uint8(s>>12) & 0x1F
+}
+
+// Increment returns the number of IDs generated by the process before
+// the Snowflake ID.
+func (s Snowflake) Increment() uint16 {
+	return uint16(s & 0xFFF)
+}
+
+// Compare returns -1 if s is older than other, 1 if s is newer than
+// other and 0 if they are the same.
+func (s Snowflake) Compare(other Snowflake) int {
+	switch {
+	case s < other:
+		return -1
+	case s > other:
+		return 1
+	}
+	return 0
+}
+
+// Before reports whether s was created before other.
+func (s Snowflake) Before(other Snowflake) bool {
+	return s < other
+}
+
+// After reports whether s was created after other.
+func (s Snowflake) After(other Snowflake) bool {
+	return s > other
+}
+
+// MarshalJSON is a helper function to marshal the Snowflake as a string.
+func (s Snowflake) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// UnmarshalJSON is a helper function to unmarshal a Snowflake from a
+// string or number. null and "" unmarshal to 0.
+func (s *Snowflake) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	id := string(bytes.Trim(data, `"`))
+	if id == "" {
+		*s = 0
+		return nil
+	}
+
+	v, err := ParseSnowflake(id)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
@@ -0,0 +1,65 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSnowflake(t *testing.T) {
+	// Example from the Discord documentation.
+	s, err := ParseSnowflake("175928847299117063")
+	if err != nil {
+		t.Fatalf("ParseSnowflake returned error: %v", err)
+	}
+
+	if want := time.Unix(0, 1462015105796*int64(time.Millisecond)); !s.Time().Equal(want) {
+		t.Errorf("Time: got %v, want %v", s.Time(), want)
+	}
+	if s.Worker() != 1 || s.Process() != 0 || s.Increment() != 7 {
+		t.Errorf("got worker %d, process %d, increment %d, want 1, 0, 7", s.Worker(), s.Process(), s.Increment())
+	}
+
+	if !NewSnowflake(s.Time()).Before(s) || NewSnowflake(s.Time().Add(time.Millisecond)).Compare(s) != 1 {
+		t.Errorf("NewSnowflake is not the lowest ID of its time")
+	}
+}
+
+func TestSnowflakeJSON(t *testing.T) {
+	var v struct {
+		A Snowflake `json:"a"`
+		B Snowflake `json:"b"`
+		C Snowflake `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": "123", "b": 456, "c": null}`), &v); err != nil {
+		t.Fatalf("unmarshal returned error: %v", err)
+	}
+	if v.A != 123 || v.B != 456 || v.C != 0 {
+		t.Errorf("got %+v", v)
+	}
+
+	data, _ := json.Marshal(v)
+	if want := `{"a":"123","b":"456","c":"0"}`; string(data) != want {
+		t.Errorf("marshal: got %s, want %s", data, want)
+	}
+}
+
+func TestCompareSnowflakes(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"175928847299117063", "175928847299117063", 0},
+		{"175928847299117064", "175928847299117063", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareSnowflakes(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareSnowflakes(%q, %q): got %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if IsSnowflake("0") || IsSnowflake("12a") || !IsSnowflake("175928847299117063") {
+		t.Errorf("IsSnowflake accepted an invalid or rejected a valid ID")
+	}
+}
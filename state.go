@@ -13,6 +13,7 @@
 package discordgo
 
 import (
+	"encoding/json"
 	"errors"
 	"sort"
 	"sync"
@@ -49,6 +50,13 @@ type State struct {
 	guildMap   map[string]*Guild
 	channelMap map[string]*Channel
 	memberMap  map[string]map[string]*Member
+
+	// The availability of the guilds of the bot, and the guilds of the
+	// READY packet which were not received yet.
+	guildAvailable map[string]bool
+	pendingGuilds  map[string]bool
+	guildsReady    bool
+	readyCount     int
 }
 
 // NewState creates an empty state.
@@ -372,6 +380,23 @@ func (s *State) Member(guildID, userID string) (*Member, error) {
 	return nil, ErrStateNotFound
 }
 
+// memberUpdate merges a member of a GUILD_MEMBER_UPDATE event into the
+// state. The event does not always carry the voice deaf and mute flags and
+// never the interaction permissions, so these are kept from the cached member.
+func (s *State) memberUpdate(member *Member) error {
+	m := *member
+
+	if old, err := s.Member(member.GuildID, member.User.ID); err == nil {
+		s.RLock()
+		m.Deaf = old.Deaf
+		m.Mute = old.Mute
+		m.Permissions = old.Permissions
+		s.RUnlock()
+	}
+
+	return s.MemberAdd(&m)
+}
+
 // RoleAdd adds a role to the current world state, or
 // updates it if it already exists.
 func (s *State) RoleAdd(guildID string, role *Role) error {
@@ -797,6 +822,14 @@ func (s *State) onReady(se *Session, r *Ready) (err error) {
 		return nil
 	}
 
+	s.loadReady(r)
+
+	return nil
+}
+
+// loadReady stores the READY data and indexes its guilds and channels.
+// The state must be locked.
+func (s *State) loadReady(r *Ready) {
 	s.Ready = *r
 
 	for _, g := range s.Guilds {
@@ -811,6 +844,50 @@ func (s *State) onReady(se *Session, r *Ready) (err error) {
 	for _, c := range s.PrivateChannels {
 		s.channelMap[c.ID] = c
 	}
+}
+
+// Snapshot serializes the guilds, channels, members and other data of the
+// state to JSON, so it can be restored with Restore, e.g. after a restart
+// which resumes the gateway session. Messages are not included.
+func (s *State) Snapshot() ([]byte, error) {
+	if s == nil {
+		return nil, ErrNilState
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return json.Marshal(&s.Ready)
+}
+
+// Restore replaces the data of the state with a snapshot created by
+// Snapshot. It should be called before the session is opened.
+func (s *State) Restore(data []byte) error {
+	if s == nil {
+		return ErrNilState
+	}
+
+	var r Ready
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.guildMap = make(map[string]*Guild)
+	s.channelMap = make(map[string]*Channel)
+	s.memberMap = make(map[string]map[string]*Member)
+	s.loadReady(&r)
+
+	// The session is resumed, so no GuildCreate events of the READY
+	// packet follow.
+	s.guildAvailable = make(map[string]bool)
+	for _, g := range s.Guilds {
+		s.guildAvailable[g.ID] = !g.Unavailable
+	}
+	s.pendingGuilds = nil
+	s.guildsReady = true
 
 	return nil
 }
@@ -843,6 +920,16 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 			t.BeforeDelete = &oldCopy
 		}
 
+		// An unavailable guild is kept until it is available again.
+		if t.Unavailable {
+			if old != nil {
+				s.Lock()
+				old.Unavailable = true
+				s.Unlock()
+			}
+			return nil
+		}
+
 		err = s.GuildRemove(t.Guild)
 	case *GuildMemberAdd:
 		// Updates the MemberCount of the guild.
@@ -858,7 +945,7 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *GuildMemberUpdate:
 		if s.TrackMembers {
-			err = s.MemberAdd(t.Member)
+			err = s.memberUpdate(t.Member)
 		}
 	case *GuildMemberRemove:
 		// Updates the MemberCount of the guild.
@@ -1011,7 +1098,7 @@ func (s *State) UserChannelPermissions(userID, channelID string) (apermissions i
 		return
 	}
 
-	return memberPermissions(guild, channel, userID, member.Roles), nil
+	return memberPermissions(guild, channel, userID, member), nil
 }
 
 // MessagePermissions returns the permissions of the author of the message
@@ -1035,7 +1122,7 @@ func (s *State) MessagePermissions(message *Message) (apermissions int64, err er
 		return
 	}
 
-	return memberPermissions(guild, channel, message.Author.ID, message.Member.Roles), nil
+	return memberPermissions(guild, channel, message.Author.ID, message.Member), nil
 }
 
 // UserColor returns the color of a user in a channel.
@@ -0,0 +1,43 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+func TestStateGuildMemberUpdate(t *testing.T) {
+	state := NewState()
+	state.GuildAdd(&Guild{ID: "guild"})
+	state.MemberAdd(&Member{
+		GuildID:  "guild",
+		User:     &User{ID: "user"},
+		JoinedAt: "2021-01-01T00:00:00Z",
+		Deaf:     true,
+		Mute:     true,
+	})
+
+	err := state.OnInterface(&Session{StateEnabled: true}, &GuildMemberUpdate{&Member{
+		GuildID:                    "guild",
+		User:                       &User{ID: "user"},
+		Nick:                       "nick",
+		Avatar:                     "avatar",
+		Flags:                      MemberFlagsDidRejoin,
+		CommunicationDisabledUntil: "2099-01-01T00:00:00Z",
+	}})
+	if err != nil {
+		t.Fatalf("OnInterface returned error: %v", err)
+	}
+
+	m, err := state.Member("guild", "user")
+	if err != nil {
+		t.Fatalf("Member returned error: %v", err)
+	}
+	if m.Nick != "nick" || m.Avatar != "avatar" || m.Flags != MemberFlagsDidRejoin {
+		t.Errorf("updated fields not merged: %+v", m)
+	}
+	if !m.TimedOut() {
+		t.Errorf("member should be timed out until %s", m.CommunicationDisabledUntil)
+	}
+	if !m.Deaf || !m.Mute || m.JoinedAt == "" {
+		t.Errorf("fields missing from the update were not preserved: %+v", m)
+	}
+}
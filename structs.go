@@ -12,6 +12,7 @@
 package discordgo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -64,6 +65,15 @@ type Session struct {
 	// e.g false = launch event handlers in their own goroutines.
 	SyncEvents bool
 
+	// Runs the event handlers on a bounded pool of workers when set,
+	// instead of inline or on a goroutine per handler call. It takes
+	// precedence over SyncEvents.
+	Dispatcher *EventDispatcher
+
+	// Records the events received from the gateway when set, e.g. to
+	// replay them later with Replay.
+	Recorder *EventRecorder
+
 	// Exposed but should not be modified by User.
 
 	// Whether the Data Websocket is ready
@@ -72,6 +82,27 @@ type Session struct {
 	// Max number of REST API retries
 	MaxRestRetries int
 
+	// Should REST methods check the permissions of the bot in the state
+	// before sending a request, and return a *MissingPermissionsError
+	// instead of a 403 response. Requests are sent unchecked when the
+	// state lacks the guild, channel or member of the bot.
+	PermissionChecks bool
+
+	// How long gateway commands like UpdateStatusComplex and
+	// RequestGuildMembers wait when the gateway rate limit is reached.
+	// Zero waits as long as needed, a negative value fails fast with
+	// ErrGatewayRateLimited.
+	GatewayRateLimitTimeout time.Duration
+
+	// Coordinates the identifies of the sessions of the bot, to respect
+	// the max_concurrency and session start limits of Discord. When nil,
+	// the sessions with the same token share a LocalIdentifyLimiter.
+	IdentifyLimiter IdentifyLimiter
+
+	// How long to wait for the guilds in the READY packet before the
+	// GuildsReady event is emitted anyway. Zero waits as long as needed.
+	GuildsReadyTimeout time.Duration
+
 	// Status stores the currect status of the websocket connection
 	// this is being tested, may stay, may go away.
 	status int32
@@ -121,11 +152,30 @@ type Session struct {
 	// stores sessions current Discord Gateway
 	gateway string
 
+	// guards sessionID and resumeGatewayURL, as onEvent changes them
+	// both inside Open and on the listen goroutine
+	gatewaySessionMu sync.RWMutex
+
 	// stores session ID of current Gateway connection
 	sessionID string
 
+	// limits the commands sent on the current Gateway connection
+	gatewayLimiter gatewayRateLimiter
+
+	// stores the Gateway to resume the current session on
+	resumeGatewayURL string
+
 	// used to make sure gateway websocket writes do not happen concurrently
 	wsMutex sync.Mutex
+
+	// cancels the identify waiting for the IdentifyLimiter, so Close does
+	// not wait for it
+	identifyMu     sync.Mutex
+	identifyCancel context.CancelFunc
+
+	// the RequestGuildMembersStream calls waiting for chunks, by nonce
+	guildMembersRequestsMu sync.Mutex
+	guildMembersRequests   map[string]*guildMembersRequest
 }
 
 // UserConnection is a Connection returned from the UserConnections endpoint
@@ -235,6 +285,14 @@ const (
 	ChannelTypeGuildStore    ChannelType = 6
 )
 
+// Block contains the ChannelType values of threads and stage channels
+const (
+	ChannelTypeGuildNewsThread    ChannelType = 10
+	ChannelTypeGuildPublicThread  ChannelType = 11
+	ChannelTypeGuildPrivateThread ChannelType = 12
+	ChannelTypeGuildStageVoice    ChannelType = 13
+)
+
 // A Channel holds all data related to an individual Discord channel.
 type Channel struct {
 	// The ID of the channel.
@@ -305,6 +363,11 @@ func (c *Channel) Mention() string {
 	return fmt.Sprintf("<#%s>", c.ID)
 }
 
+// IsThread reports whether the channel is a thread.
+func (c *Channel) IsThread() bool {
+	return c.Type == ChannelTypeGuildNewsThread || c.Type == ChannelTypeGuildPublicThread || c.Type == ChannelTypeGuildPrivateThread
+}
+
 // A ChannelEdit holds Channel Field data for a channel edit.
 type ChannelEdit struct {
 	Name                 string                 `json:"name,omitempty"`
@@ -891,6 +954,15 @@ type Member struct {
 
 	// Total permissions of the member in the channel, including overrides, returned when in the interaction object.
 	Permissions int64 `json:"permissions,string"`
+
+	// The hash of the guild specific avatar of the member, if they have one.
+	Avatar string `json:"avatar"`
+
+	// The flags of the member, a combination of MemberFlags.
+	Flags MemberFlags `json:"flags"`
+
+	// When the timeout of the member expires, empty if they are not timed out.
+	CommunicationDisabledUntil Timestamp `json:"communication_disabled_until"`
 }
 
 // Mention creates a member mention
@@ -898,6 +970,104 @@ func (m *Member) Mention() string {
 	return "<@!" + m.User.ID + ">"
 }
 
+// AvatarURL returns the URL of the guild specific avatar of the member,
+// or of their user avatar if they have none.
+//    size:    The size of the avatar as a power of two
+//             if size is an empty string, no size parameter will
+//             be added to the URL.
+func (m *Member) AvatarURL(size string) string {
+	if m.Avatar == "" {
+		return m.User.AvatarURL(size)
+	}
+
+	var URL string
+	if strings.HasPrefix(m.Avatar, "a_") {
+		URL = EndpointGuildMemberAvatarAnimated(m.GuildID, m.User.ID, m.Avatar)
+	} else {
+		URL = EndpointGuildMemberAvatar(m.GuildID, m.User.ID, m.Avatar)
+	}
+
+	if size != "" {
+		return URL + "?size=" + size
+	}
+	return URL
+}
+
+// TimedOut returns true if the member is currently timed out and can't
+// communicate in the guild.
+func (m *Member) TimedOut() bool {
+	if m.CommunicationDisabledUntil == "" {
+		return false
+	}
+
+	until, err := m.CommunicationDisabledUntil.Parse()
+	if err != nil {
+		return false
+	}
+	return time.Now().Before(until)
+}
+
+// MemberFlags is the flags of a guild member (see MemberFlags* consts)
+// https://discord.com/developers/docs/resources/guild#guild-member-object-guild-member-flags
+type MemberFlags int
+
+// Valid MemberFlags values
+const (
+	MemberFlagsDidRejoin            MemberFlags = 1 << 0
+	MemberFlagsCompletedOnboarding  MemberFlags = 1 << 1
+	MemberFlagsBypassesVerification MemberFlags = 1 << 2
+	MemberFlagsStartedOnboarding    MemberFlags = 1 << 3
+)
+
+// GuildMemberParams stores the data to edit a guild member with
+// GuildMemberEditComplex. Only the fields which are not nil are changed.
+type GuildMemberParams struct {
+	// The nickname of the member, an empty string resets it.
+	Nick *string `json:"nick,omitempty"`
+
+	// The IDs of the roles of the member.
+	Roles *[]string `json:"roles,omitempty"`
+
+	// The voice channel to move the member to, an empty string
+	// disconnects them from voice.
+	ChannelID *string `json:"channel_id,omitempty"`
+
+	// Whether the member is muted or deafened in voice channels.
+	Mute *bool `json:"mute,omitempty"`
+	Deaf *bool `json:"deaf,omitempty"`
+
+	// When the timeout of the member expires, a zero time removes it.
+	// Timeouts can be at most 28 days long.
+	CommunicationDisabledUntil *time.Time `json:"communication_disabled_until,omitempty"`
+}
+
+// MarshalJSON is a helper function to marshal GuildMemberParams.
+func (p GuildMemberParams) MarshalJSON() ([]byte, error) {
+	type guildMemberParams GuildMemberParams
+
+	v := struct {
+		guildMemberParams
+		ChannelID                  json.RawMessage `json:"channel_id,omitempty"`
+		CommunicationDisabledUntil json.RawMessage `json:"communication_disabled_until,omitempty"`
+	}{guildMemberParams: guildMemberParams(p)}
+
+	if p.ChannelID != nil {
+		v.ChannelID = json.RawMessage("null")
+		if *p.ChannelID != "" {
+			v.ChannelID, _ = json.Marshal(*p.ChannelID)
+		}
+	}
+
+	if p.CommunicationDisabledUntil != nil {
+		v.CommunicationDisabledUntil = json.RawMessage("null")
+		if !p.CommunicationDisabledUntil.IsZero() {
+			v.CommunicationDisabledUntil, _ = json.Marshal(p.CommunicationDisabledUntil.Format(time.RFC3339))
+		}
+	}
+
+	return json.Marshal(v)
+}
+
 // A Settings stores data for a specific users Discord client settings.
 type Settings struct {
 	RenderEmbeds           bool               `json:"render_embeds"`
@@ -1027,6 +1197,10 @@ type AuditLogChange struct {
 	NewValue interface{}        `json:"new_value"`
 	OldValue interface{}        `json:"old_value"`
 	Key      *AuditLogChangeKey `json:"key"`
+
+	// The raw values, decoded by Values, DecodeNew and DecodeOld.
+	rawNewValue json.RawMessage
+	rawOldValue json.RawMessage
 }
 
 // AuditLogChangeKey value for AuditLogChange
@@ -1082,6 +1256,7 @@ const (
 	AuditLogChangeKeyEnableEmoticons            AuditLogChangeKey = "enable_emoticons"
 	AuditLogChangeKeyExpireBehavior             AuditLogChangeKey = "expire_behavior"
 	AuditLogChangeKeyExpireGracePeriod          AuditLogChangeKey = "expire_grace_period"
+	AuditLogChangeKeyCommunicationDisabledUntil AuditLogChangeKey = "communication_disabled_until"
 )
 
 // AuditLogOptions optional data for the AuditLog
@@ -1128,6 +1303,9 @@ const (
 	AuditLogActionMemberBanRemove  AuditLogAction = 23
 	AuditLogActionMemberUpdate     AuditLogAction = 24
 	AuditLogActionMemberRoleUpdate AuditLogAction = 25
+	AuditLogActionMemberMove       AuditLogAction = 26
+	AuditLogActionMemberDisconnect AuditLogAction = 27
+	AuditLogActionBotAdd           AuditLogAction = 28
 
 	AuditLogActionRoleCreate AuditLogAction = 30
 	AuditLogActionRoleUpdate AuditLogAction = 31
@@ -1367,6 +1545,27 @@ const (
 	PermissionManageEmojis    = 0x0000000040000000
 )
 
+// Constants for the different bit offsets of thread, event, moderation
+// and newer permissions
+const (
+	PermissionManageEvents                     = 0x0000000200000000
+	PermissionManageThreads                    = 0x0000000400000000
+	PermissionCreatePublicThreads              = 0x0000000800000000
+	PermissionCreatePrivateThreads             = 0x0000001000000000
+	PermissionUseExternalStickers              = 0x0000002000000000
+	PermissionSendMessagesInThreads            = 0x0000004000000000
+	PermissionUseEmbeddedActivities            = 0x0000008000000000
+	PermissionModerateMembers                  = 0x0000010000000000
+	PermissionViewCreatorMonetizationAnalytics = 0x0000020000000000
+	PermissionUseSoundboard                    = 0x0000040000000000
+	PermissionCreateGuildExpressions           = 0x0000080000000000
+	PermissionCreateEvents                     = 0x0000100000000000
+	PermissionUseExternalSounds                = 0x0000200000000000
+	PermissionSendVoiceMessages                = 0x0000400000000000
+	PermissionSendPolls                        = 0x0002000000000000
+	PermissionUseExternalApps                  = 0x0004000000000000
+)
+
 // Constants for the different bit offsets of general permissions
 const (
 	PermissionCreateInstantInvite = 0x0000000000000001
@@ -1387,7 +1586,17 @@ const (
 		PermissionEmbedLinks |
 		PermissionAttachFiles |
 		PermissionReadMessageHistory |
-		PermissionMentionEveryone
+		PermissionMentionEveryone |
+		PermissionUseExternalEmojis |
+		PermissionUseSlashCommands |
+		PermissionManageThreads |
+		PermissionCreatePublicThreads |
+		PermissionCreatePrivateThreads |
+		PermissionUseExternalStickers |
+		PermissionSendMessagesInThreads |
+		PermissionSendVoiceMessages |
+		PermissionSendPolls |
+		PermissionUseExternalApps
 	PermissionAllVoice = PermissionViewChannel |
 		PermissionVoiceConnect |
 		PermissionVoiceSpeak |
@@ -1395,21 +1604,35 @@ const (
 		PermissionVoiceDeafenMembers |
 		PermissionVoiceMoveMembers |
 		PermissionVoiceUseVAD |
-		PermissionVoicePrioritySpeaker
+		PermissionVoicePrioritySpeaker |
+		PermissionVoiceStreamVideo |
+		PermissionVoiceRequestToSpeak |
+		PermissionUseEmbeddedActivities |
+		PermissionUseSoundboard |
+		PermissionUseExternalSounds
 	PermissionAllChannel = PermissionAllText |
 		PermissionAllVoice |
 		PermissionCreateInstantInvite |
 		PermissionManageRoles |
 		PermissionManageChannels |
 		PermissionAddReactions |
-		PermissionViewAuditLogs
+		PermissionViewAuditLogs |
+		PermissionManageWebhooks |
+		PermissionManageEvents |
+		PermissionCreateEvents
 	PermissionAll = PermissionAllChannel |
 		PermissionKickMembers |
 		PermissionBanMembers |
 		PermissionManageServer |
 		PermissionAdministrator |
 		PermissionManageWebhooks |
-		PermissionManageEmojis
+		PermissionManageEmojis |
+		PermissionChangeNickname |
+		PermissionManageNicknames |
+		PermissionViewGuildInsights |
+		PermissionModerateMembers |
+		PermissionViewCreatorMonetizationAnalytics |
+		PermissionCreateGuildExpressions
 )
 
 // Block contains Discord JSON Error Response codes
@@ -0,0 +1,35 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGuildMemberParamsMarshalJSON(t *testing.T) {
+	nick := "nick"
+	disconnect := ""
+	until := time.Date(2022, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		params GuildMemberParams
+		want   string
+	}{
+		{GuildMemberParams{}, `{}`},
+		{GuildMemberParams{Nick: &nick}, `{"nick":"nick"}`},
+		{GuildMemberParams{ChannelID: &disconnect}, `{"channel_id":null}`},
+		{GuildMemberParams{CommunicationDisabledUntil: &time.Time{}}, `{"communication_disabled_until":null}`},
+		{GuildMemberParams{CommunicationDisabledUntil: &until}, `{"communication_disabled_until":"2022-01-02T03:04:05Z"}`},
+	}
+
+	for _, test := range tests {
+		got, err := json.Marshal(&test.params)
+		if err != nil {
+			t.Errorf("Marshal returned error: %v", err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("Marshal: got %s, want %s", got, test.want)
+		}
+	}
+}
@@ -104,7 +104,8 @@ func constCase(name string) string {
 
 func isDiscordEvent(name string) bool {
 	switch {
-	case name == "Connect", name == "Disconnect", name == "Event", name == "RateLimit", name == "Interface":
+	case name == "Connect", name == "Disconnect", name == "Event", name == "RateLimit", name == "Interface", name == "GatewayFatalError",
+		name == "GuildAvailable", name == "GuildJoin", name == "GuildsReady":
 		return false
 	default:
 		return true
@@ -3,6 +3,7 @@ package discordgo
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -13,13 +14,17 @@ import (
 
 // SnowflakeTimestamp returns the creation time of a Snowflake ID relative to the creation of Discord.
 func SnowflakeTimestamp(ID string) (t time.Time, err error) {
-	i, err := strconv.ParseInt(ID, 10, 64)
+	s, err := ParseSnowflake(ID)
 	if err != nil {
 		return
 	}
-	timestamp := (i >> 22) + 1420070400000
-	t = time.Unix(0, timestamp*1000000)
-	return
+	return s.Time(), nil
+}
+
+// SnowflakeFromTime returns the lowest Snowflake ID created at t, to page
+// through lists of items created before or after t.
+func SnowflakeFromTime(t time.Time) string {
+	return NewSnowflake(t).String()
 }
 
 // MultipartBodyWithJSON returns the contentType and body for a discord request
@@ -34,13 +39,99 @@ func MultipartBodyWithJSON(data interface{}, files []*File) (requestContentType
 		return
 	}
 
-	var p io.Writer
+	readers := make([]io.Reader, len(files))
+	for i, file := range files {
+		readers[i] = file.Reader
+	}
+
+	err = writeMultipart(body, bodywriter.Boundary(), payload, files, readers)
+	if err != nil {
+		return
+	}
+
+	return bodywriter.FormDataContentType(), body.Bytes(), nil
+}
+
+// errUploadRetried stops the upload of an attempt which is retried.
+var errUploadRetried = errors.New("upload retried")
+
+// multipartStreamWithJSON returns the contentType and a body func for a
+// streamed multipart discord request. Every call of body starts a new upload
+// in the background, which lets a request be retried without buffering the
+// files in memory.
+// data  : The object to encode for payload_json in the multipart request
+// files : Files to include in the request
+func multipartStreamWithJSON(data interface{}, files []*File) (requestContentType string, body func() (io.Reader, error), err error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	boundary := multipart.NewWriter(nil).Boundary()
+	sources := make([]*fileSource, len(files))
+	for i, file := range files {
+		sources[i] = &fileSource{file: file}
+	}
+
+	// The previous attempt may still be reading the files when a request
+	// is retried, as the transport only closes its end of the pipe.
+	var prevReader *io.PipeReader
+	var prevDone chan struct{}
+
+	body = func() (io.Reader, error) {
+		if prevDone != nil {
+			prevReader.CloseWithError(errUploadRetried)
+			<-prevDone
+		}
+
+		readers := make([]io.Reader, len(files))
+		closers := make([]io.Closer, 0, len(files))
+		for i, src := range sources {
+			r, c, err := src.open()
+			if err != nil {
+				for _, c := range closers {
+					c.Close()
+				}
+				return nil, err
+			}
+			readers[i] = r
+			if c != nil {
+				closers = append(closers, c)
+			}
+		}
+
+		pr, pw := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer func() {
+				for _, c := range closers {
+					c.Close()
+				}
+			}()
+			pw.CloseWithError(writeMultipart(pw, boundary, payload, files, readers))
+		}()
+		prevReader, prevDone = pr, done
+
+		return pr, nil
+	}
+
+	return "multipart/form-data; boundary=" + boundary, body, nil
+}
+
+// writeMultipart writes the multipart body of a request with the given
+// payload_json and file contents to w.
+func writeMultipart(w io.Writer, boundary string, payload []byte, files []*File, readers []io.Reader) (err error) {
+	bodywriter := multipart.NewWriter(w)
+	if err = bodywriter.SetBoundary(boundary); err != nil {
+		return
+	}
 
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", `form-data; name="payload_json"`)
 	h.Set("Content-Type", "application/json")
 
-	p, err = bodywriter.CreatePart(h)
+	p, err := bodywriter.CreatePart(h)
 	if err != nil {
 		return
 	}
@@ -51,7 +142,7 @@ func MultipartBodyWithJSON(data interface{}, files []*File) (requestContentType
 
 	for i, file := range files {
 		h := make(textproto.MIMEHeader)
-		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i, quoteEscaper.Replace(file.Name)))
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, quoteEscaper.Replace(file.filename())))
 		contentType := file.ContentType
 		if contentType == "" {
 			contentType = "application/octet-stream"
@@ -63,15 +154,118 @@ func MultipartBodyWithJSON(data interface{}, files []*File) (requestContentType
 			return
 		}
 
-		if _, err = io.Copy(p, file.Reader); err != nil {
+		if file.Progress != nil {
+			p = &progressWriter{w: p, progress: file.Progress}
+		}
+
+		if _, err = io.Copy(p, readers[i]); err != nil {
 			return
 		}
 	}
 
-	err = bodywriter.Close()
-	if err != nil {
-		return
+	return bodywriter.Close()
+}
+
+// fileSource hands out the contents of a File for every attempt of an upload.
+type fileSource struct {
+	file     *File
+	attempts int
+	offset   int64
+
+	// The contents read so far from a Reader which is not an io.Seeker.
+	buf bytes.Buffer
+}
+
+// open returns the reader for the next upload attempt of the file and, if
+// it has to be closed after the upload, its closer. The first attempt reads
+// from Reader, later ones re-open the file or rewind a seekable Reader.
+// Other Readers are kept in memory as they are read, and later attempts
+// read the kept contents before the rest of Reader.
+func (fs *fileSource) open() (io.Reader, io.Closer, error) {
+	fs.attempts++
+
+	if fs.file.Open != nil {
+		rc, err := fs.file.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		return rc, rc, nil
 	}
 
-	return bodywriter.FormDataContentType(), body.Bytes(), nil
+	seeker, seekable := fs.file.Reader.(io.Seeker)
+	if !seekable {
+		return io.MultiReader(bytes.NewReader(fs.buf.Bytes()), io.TeeReader(fs.file.Reader, &fs.buf)), nil, nil
+	}
+
+	if fs.attempts == 1 {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, err
+		}
+		fs.offset = offset
+		return fs.file.Reader, nil, nil
+	}
+
+	if _, err := seeker.Seek(fs.offset, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	return fs.file.Reader, nil, nil
+}
+
+// progressWriter reports the number of bytes written through it.
+type progressWriter struct {
+	w        io.Writer
+	sent     int64
+	progress func(sent int64)
+}
+
+func (pw *progressWriter) Write(b []byte) (n int, err error) {
+	n, err = pw.w.Write(b)
+	pw.sent += int64(n)
+	pw.progress(pw.sent)
+	return
+}
+
+// attachmentsWithFiles returns a copy of attachments with an entry added for
+// every file that is not listed yet. New uploads are referenced by their
+// index in files, which is how Discord matches them to the files[n] parts.
+func attachmentsWithFiles(attachments []*MessageAttachment, files []*File) []*MessageAttachment {
+	st := make([]*MessageAttachment, len(attachments), len(attachments)+len(files))
+	copy(st, attachments)
+
+	listed := make(map[string]bool, len(attachments))
+	for _, a := range attachments {
+		listed[a.ID] = true
+	}
+
+	for i, file := range files {
+		id := strconv.Itoa(i)
+		if listed[id] {
+			continue
+		}
+		st = append(st, &MessageAttachment{
+			ID:          id,
+			Filename:    file.filename(),
+			Description: file.Description,
+		})
+	}
+
+	return st
+}
+
+// editAttachments returns the attachments of a message edit which adds
+// files. Without attachments the existing ones are kept, which leaves no
+// place for the descriptions of the files.
+func editAttachments(attachments *[]*MessageAttachment, files []*File) (*[]*MessageAttachment, error) {
+	if attachments == nil {
+		for _, file := range files {
+			if file.Description != "" {
+				return nil, ErrEditFileDescription
+			}
+		}
+		return nil, nil
+	}
+
+	st := attachmentsWithFiles(*attachments, files)
+	return &st, nil
 }
@@ -1,6 +1,14 @@
 package discordgo
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strings"
 	"testing"
 	"time"
 )
@@ -19,3 +27,196 @@ func TestSnowflakeTimestamp(t *testing.T) {
 		t.Errorf("parsed time incorrect: got %v, want %v", parsedTimestamp, correctTimestamp)
 	}
 }
+
+func TestMultipartBodyWithJSON(t *testing.T) {
+	files := []*File{
+		{Name: "a.png", Reader: strings.NewReader("a"), Description: "first"},
+		{Name: "b.png", Reader: strings.NewReader("b"), Spoiler: true},
+	}
+	data := &MessageSend{
+		Embeds:      []*MessageEmbed{{Image: &MessageEmbedImage{URL: files[0].AttachmentURL()}}},
+		Attachments: attachmentsWithFiles(nil, files),
+	}
+
+	contentType, body, err := MultipartBodyWithJSON(data, files)
+	if err != nil {
+		t.Fatalf("MultipartBodyWithJSON returned error: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("invalid content type %q: %v", contentType, err)
+	}
+
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+
+	part, err := r.NextPart()
+	if err != nil {
+		t.Fatalf("reading payload_json part: %v", err)
+	}
+	var payload MessageSend
+	if err = json.NewDecoder(part).Decode(&payload); err != nil {
+		t.Fatalf("decoding payload_json: %v", err)
+	}
+	if len(payload.Attachments) != 2 {
+		t.Fatalf("attachments length: got %d, want 2", len(payload.Attachments))
+	}
+	if a := payload.Attachments[0]; a.ID != "0" || a.Description != "first" {
+		t.Errorf("first attachment: got %+v, want ID 0 with description", a)
+	}
+	if a := payload.Attachments[1]; a.ID != "1" || a.Filename != "SPOILER_b.png" {
+		t.Errorf("second attachment: got %+v, want ID 1 with spoiler filename", a)
+	}
+	if url := payload.Embeds[0].Image.URL; url != "attachment://a.png" {
+		t.Errorf("embed image url: got %q, want %q", url, "attachment://a.png")
+	}
+
+	for i, want := range []string{"a.png", "SPOILER_b.png"} {
+		part, err = r.NextPart()
+		if err != nil {
+			t.Fatalf("reading file part %d: %v", i, err)
+		}
+		if name, wantName := part.FormName(), fmt.Sprintf("files[%d]", i); name != wantName {
+			t.Errorf("file part name: got %q, want %q", name, wantName)
+		}
+		if name := part.FileName(); name != want {
+			t.Errorf("file part filename: got %q, want %q", name, want)
+		}
+		if _, err = ioutil.ReadAll(part); err != nil {
+			t.Errorf("reading file part %d: %v", i, err)
+		}
+	}
+}
+
+func TestEditAttachments(t *testing.T) {
+	files := []*File{{Name: "a.png", Description: "alt text"}}
+
+	if _, err := editAttachments(nil, files); err != ErrEditFileDescription {
+		t.Errorf("description without attachments: got error %v, want %v", err, ErrEditFileDescription)
+	}
+	if a, err := editAttachments(nil, []*File{{Name: "b.png", Spoiler: true}}); a != nil || err != nil {
+		t.Errorf("file without description: got %v, %v, want the attachments untouched", a, err)
+	}
+
+	kept := []*MessageAttachment{{ID: "123"}}
+	a, err := editAttachments(&kept, files)
+	if err != nil {
+		t.Fatalf("editAttachments returned error: %v", err)
+	}
+	if len(*a) != 2 || (*a)[0].ID != "123" || (*a)[1].Description != "alt text" {
+		t.Errorf("got attachments %+v, want the kept one and the described file", *a)
+	}
+}
+
+func TestMultipartStreamWithJSONRetry(t *testing.T) {
+	var sent int64
+	seekable := &File{Name: "a.txt", Reader: strings.NewReader("contents"), Progress: func(n int64) { sent = n }}
+	stream := &File{Name: "b.txt", Reader: ioutil.NopCloser(strings.NewReader("stream"))}
+
+	_, body, err := multipartStreamWithJSON(&MessageSend{}, []*File{seekable})
+	if err != nil {
+		t.Fatalf("multipartStreamWithJSON returned error: %v", err)
+	}
+
+	var uploads [2][]byte
+	for i := range uploads {
+		r, err := body()
+		if err != nil {
+			t.Fatalf("attempt %d: body returned error: %v", i, err)
+		}
+		if uploads[i], err = ioutil.ReadAll(r); err != nil {
+			t.Fatalf("attempt %d: reading body: %v", i, err)
+		}
+	}
+	if !bytes.Equal(uploads[0], uploads[1]) || !bytes.Contains(uploads[1], []byte("contents")) {
+		t.Errorf("retried upload differs from first upload:\n%s\n%s", uploads[0], uploads[1])
+	}
+	if sent != int64(len("contents")) {
+		t.Errorf("progress: got %d, want %d", sent, len("contents"))
+	}
+
+	_, body, err = multipartStreamWithJSON(&MessageSend{}, []*File{stream})
+	if err != nil {
+		t.Fatalf("multipartStreamWithJSON returned error: %v", err)
+	}
+	// A non seekable file is retried from the contents kept in memory.
+	r, err := body()
+	if err != nil {
+		t.Fatalf("body returned error: %v", err)
+	}
+	first, _ := ioutil.ReadAll(r)
+	if !bytes.Contains(first, []byte("stream")) {
+		t.Fatalf("first upload does not contain the file:\n%s", first)
+	}
+	r, err = body()
+	if err != nil {
+		t.Fatalf("retrying a non seekable file: body returned error: %v", err)
+	}
+	if retry, _ := ioutil.ReadAll(r); !bytes.Equal(first, retry) {
+		t.Errorf("retried upload differs from first upload:\n%s\n%s", first, retry)
+	}
+
+	// When the first attempt is abandoned halfway, the retry sends the
+	// kept part and then the rest of the file.
+	contents := bytes.Repeat([]byte("0123456789"), 100000)
+	_, body, err = multipartStreamWithJSON(&MessageSend{}, []*File{{Name: "c.bin", Reader: ioutil.NopCloser(bytes.NewReader(contents))}})
+	if err != nil {
+		t.Fatalf("multipartStreamWithJSON returned error: %v", err)
+	}
+	if r, err = body(); err != nil {
+		t.Fatalf("body returned error: %v", err)
+	}
+	if _, err = io.ReadFull(r, make([]byte, 64*1024)); err != nil {
+		t.Fatalf("reading first attempt: %v", err)
+	}
+	r.(io.Closer).Close()
+	if r, err = body(); err != nil {
+		t.Fatalf("retry: body returned error: %v", err)
+	}
+	if upload, _ := ioutil.ReadAll(r); !bytes.Contains(upload, contents) {
+		t.Errorf("retried upload does not contain the whole non seekable file")
+	}
+}
+
+// slowReader is a file which takes a while to read.
+type slowReader struct {
+	*bytes.Reader
+}
+
+func (r slowReader) Read(b []byte) (int, error) {
+	time.Sleep(10 * time.Millisecond)
+	return r.Reader.Read(b)
+}
+
+func TestMultipartStreamWithJSONRetryWhileUploading(t *testing.T) {
+	contents := bytes.Repeat([]byte("0123456789"), 100000)
+	file := &File{Name: "a.bin", Reader: slowReader{bytes.NewReader(contents)}}
+
+	_, body, err := multipartStreamWithJSON(&MessageSend{}, []*File{file})
+	if err != nil {
+		t.Fatalf("multipartStreamWithJSON returned error: %v", err)
+	}
+
+	// The first attempt is abandoned while its writer reads the next part
+	// of the file, like a transport which closes the body after an error.
+	r, err := body()
+	if err != nil {
+		t.Fatalf("body returned error: %v", err)
+	}
+	if _, err := io.ReadFull(r, make([]byte, 64*1024)); err != nil {
+		t.Fatalf("reading first attempt: %v", err)
+	}
+	r.(io.Closer).Close()
+
+	r, err = body()
+	if err != nil {
+		t.Fatalf("retry: body returned error: %v", err)
+	}
+	upload, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading retry: %v", err)
+	}
+	if !bytes.Contains(upload, contents) {
+		t.Errorf("retried upload does not contain the whole file")
+	}
+}
@@ -10,6 +10,7 @@
 package discordgo
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -27,6 +28,10 @@ import (
 // Code related to both VoiceConnection Websocket and UDP connections.
 // ------------------------------------------------------------------------------------------------
 
+// voiceServerUpdateTimeout is how long a connection closed with 4014 waits
+// for a VOICE_SERVER_UPDATE before it is treated as kicked.
+var voiceServerUpdateTimeout = 5 * time.Second
+
 // A VoiceConnection struct holds all the data and functions related to a Discord Voice Connection.
 type VoiceConnection struct {
 	sync.RWMutex
@@ -60,19 +65,35 @@ type VoiceConnection struct {
 	// Used to allow blocking until connected
 	connected chan bool
 
+	// Closed and reset when the connection becomes ready, see WaitReady
+	readyWait chan struct{}
+
+	// If true, the connection has been ready before and the next ready
+	// transition is reported as a VoiceResumed event.
+	wasReady bool
+
 	// Used to pass the sessionid from onVoiceStateUpdate
 	// sessionRecv chan string UNUSED ATM
 
 	op4 voiceOP4
 	op2 voiceOP2
 
-	voiceSpeakingUpdateHandlers []VoiceSpeakingUpdateHandler
+	// Serializes the handling of voice server updates
+	serverUpdateMu sync.Mutex
+
+	handlers []*voiceEventHandlerInstance
 }
 
 // VoiceSpeakingUpdateHandler type provides a function definition for the
 // VoiceSpeakingUpdate event
 type VoiceSpeakingUpdateHandler func(vc *VoiceConnection, vs *VoiceSpeakingUpdate)
 
+// voiceEventHandlerInstance wraps a handler registered with AddHandler so
+// it can be removed again.
+type voiceEventHandlerInstance struct {
+	handler func(*VoiceConnection, interface{})
+}
+
 // Speaking sends a speaking notification to Discord over the voice websocket.
 // This must be sent as true prior to sending audio and should be set to false
 // once finished sending audio.
@@ -138,6 +159,17 @@ func (v *VoiceConnection) ChangeChannel(channelID string, mute, deaf bool) (err
 // and udp connections to Discord.
 func (v *VoiceConnection) Disconnect() (err error) {
 
+	// Remove the connection before Discord confirms the disconnect, so
+	// the voice state update is not mistaken for a kick and a running
+	// reconnect gives up.
+	v.log(LogInformational, "Deleting VoiceConnection %s", v.GuildID)
+
+	v.session.Lock()
+	if v.session.VoiceConnections[v.GuildID] == v {
+		delete(v.session.VoiceConnections, v.GuildID)
+	}
+	v.session.Unlock()
+
 	// Send a OP4 with a nil channel to disconnect
 	v.Lock()
 	if v.sessionID != "" {
@@ -152,12 +184,6 @@ func (v *VoiceConnection) Disconnect() (err error) {
 	// Close websocket and udp connections
 	v.Close()
 
-	v.log(LogInformational, "Deleting VoiceConnection %s", v.GuildID)
-
-	v.session.Lock()
-	delete(v.session.VoiceConnections, v.GuildID)
-	v.session.Unlock()
-
 	return
 }
 
@@ -167,10 +193,23 @@ func (v *VoiceConnection) Close() {
 	v.log(LogInformational, "called")
 
 	v.Lock()
-	defer v.Unlock()
+	wasConnected := v.closeConnections(true)
+	v.Unlock()
+
+	if wasConnected {
+		v.handle(&VoiceDisconnect{})
+	}
+}
+
+// closeConnections closes the ws and udp connections and stops the
+// heartbeat and opus goroutines. With wait it gives Discord a second to
+// close the websocket after the close frame. It reports whether a
+// websocket was open. The VoiceConnection must be locked.
+func (v *VoiceConnection) closeConnections(wait bool) (wasConnected bool) {
 
 	v.Ready = false
 	v.speaking = false
+	wasConnected = v.wsConn != nil
 
 	if v.close != nil {
 		v.log(LogInformational, "closing v.close")
@@ -200,7 +239,9 @@ func (v *VoiceConnection) Close() {
 		}
 
 		// TODO: Wait for Discord to actually close the connection.
-		time.Sleep(1 * time.Second)
+		if wait {
+			time.Sleep(1 * time.Second)
+		}
 
 		v.log(LogInformational, "closing websocket")
 		err = v.wsConn.Close()
@@ -210,14 +251,189 @@ func (v *VoiceConnection) Close() {
 
 		v.wsConn = nil
 	}
+
+	return
 }
 
-// AddHandler adds a Handler for VoiceSpeakingUpdate events.
-func (v *VoiceConnection) AddHandler(h VoiceSpeakingUpdateHandler) {
+// AddHandler adds a handler for VoiceConnection events and returns a
+// function that removes it again.
+//
+// The handler must be a VoiceSpeakingUpdateHandler or a function with the
+// signature func(*VoiceConnection, *T), where T is one of the voice events
+// defined below (VoiceSpeakingUpdate, VoiceConnecting, VoiceReady,
+// VoiceResumed, VoiceDisconnect, VoiceChannelMove, VoiceRegionChange or
+// VoiceKicked). Unknown handler types are ignored with an error log.
+func (v *VoiceConnection) AddHandler(handler interface{}) func() {
+
+	h := voiceHandlerForInterface(handler)
+	if h == nil {
+		v.log(LogError, "invalid voice handler type, handler will never be called")
+		return func() {}
+	}
+
+	ehi := &voiceEventHandlerInstance{h}
+
 	v.Lock()
-	defer v.Unlock()
+	v.handlers = append(v.handlers, ehi)
+	v.Unlock()
 
-	v.voiceSpeakingUpdateHandlers = append(v.voiceSpeakingUpdateHandlers, h)
+	return func() {
+		v.Lock()
+		defer v.Unlock()
+
+		for i := range v.handlers {
+			if v.handlers[i] == ehi {
+				v.handlers = append(v.handlers[:i], v.handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// handle calls every handler registered for the given event. Handlers are
+// called synchronously and in order, so it must never be called while
+// holding the VoiceConnection lock.
+func (v *VoiceConnection) handle(i interface{}) {
+
+	v.RLock()
+	handlers := make([]*voiceEventHandlerInstance, len(v.handlers))
+	copy(handlers, v.handlers)
+	v.RUnlock()
+
+	for _, ehi := range handlers {
+		ehi.handler(v, i)
+	}
+}
+
+// voiceHandlerForInterface returns a generic handler wrapping the typed
+// handler passed to AddHandler, or nil if the type is not supported.
+func voiceHandlerForInterface(handler interface{}) func(*VoiceConnection, interface{}) {
+	switch h := handler.(type) {
+	case VoiceSpeakingUpdateHandler:
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceSpeakingUpdate); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceSpeakingUpdate):
+		return voiceHandlerForInterface(VoiceSpeakingUpdateHandler(h))
+	case func(*VoiceConnection, *VoiceConnecting):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceConnecting); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceReady):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceReady); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceResumed):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceResumed); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceDisconnect):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceDisconnect); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceChannelMove):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceChannelMove); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceRegionChange):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceRegionChange); ok {
+				h(v, t)
+			}
+		}
+	case func(*VoiceConnection, *VoiceKicked):
+		return func(v *VoiceConnection, i interface{}) {
+			if t, ok := i.(*VoiceKicked); ok {
+				h(v, t)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WaitReady blocks until the VoiceConnection is ready to send and receive
+// audio or the context is done, in which case the context error is returned.
+func (v *VoiceConnection) WaitReady(ctx context.Context) error {
+
+	for {
+		v.Lock()
+		if v.Ready {
+			v.Unlock()
+			return nil
+		}
+		if v.readyWait == nil {
+			v.readyWait = make(chan struct{})
+		}
+		wait := v.readyWait
+		v.Unlock()
+
+		select {
+		case <-wait:
+			// check Ready again, the connection may have been closed since
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// setReady marks the VoiceConnection as ready, wakes up everything blocked
+// in WaitReady and sends a VoiceReady or VoiceResumed event.
+func (v *VoiceConnection) setReady() {
+
+	v.Lock()
+	v.Ready = true
+	resumed := v.wasReady
+	v.wasReady = true
+	if v.readyWait != nil {
+		close(v.readyWait)
+		v.readyWait = nil
+	}
+	v.Unlock()
+
+	if resumed {
+		v.handle(&VoiceResumed{})
+	} else {
+		v.handle(&VoiceReady{})
+	}
+}
+
+// kicked tears down a VoiceConnection that was disconnected by Discord or
+// someone in the guild and sends a VoiceKicked event. It is safe to call
+// more than once, only the first call has any effect.
+func (v *VoiceConnection) kicked() {
+
+	v.session.Lock()
+	current, exists := v.session.VoiceConnections[v.GuildID]
+	if exists && current == v {
+		delete(v.session.VoiceConnections, v.GuildID)
+	}
+	v.session.Unlock()
+
+	if !exists || current != v {
+		return
+	}
+
+	v.Lock()
+	channelID := v.ChannelID
+	v.sessionID = ""
+	v.Unlock()
+
+	v.Close()
+
+	v.handle(&VoiceKicked{ChannelID: channelID})
 }
 
 // VoiceSpeakingUpdate is a struct for a VoiceSpeakingUpdate event.
@@ -227,6 +443,46 @@ type VoiceSpeakingUpdate struct {
 	Speaking bool   `json:"speaking"`
 }
 
+// VoiceConnecting is sent when a VoiceConnection starts connecting to a
+// voice server.
+type VoiceConnecting struct {
+	Endpoint string
+}
+
+// VoiceReady is sent when a VoiceConnection is ready to send and receive
+// audio for the first time.
+type VoiceReady struct{}
+
+// VoiceResumed is sent when a VoiceConnection is ready to send and receive
+// audio again after it was interrupted, e.g. after a reconnect or a change
+// of voice server.
+type VoiceResumed struct{}
+
+// VoiceDisconnect is sent when the websocket and udp connections of a
+// VoiceConnection are closed.
+type VoiceDisconnect struct{}
+
+// VoiceChannelMove is sent when the session user is moved to another voice
+// channel of the same guild by someone else.
+type VoiceChannelMove struct {
+	OldChannelID string
+	ChannelID    string
+}
+
+// VoiceRegionChange is sent when Discord moves a VoiceConnection to a
+// different voice server, for example because the channel region changed.
+type VoiceRegionChange struct {
+	OldEndpoint string
+	Endpoint    string
+}
+
+// VoiceKicked is sent when the session user was disconnected from voice
+// by someone else or because the channel was deleted. The VoiceConnection
+// is removed from the Session and will not reconnect.
+type VoiceKicked struct {
+	ChannelID string
+}
+
 // ------------------------------------------------------------------------------------------------
 // Unexported Internal Functions Below.
 // ------------------------------------------------------------------------------------------------
@@ -254,22 +510,14 @@ func (v *VoiceConnection) waitUntilConnected() error {
 
 	v.log(LogInformational, "called")
 
-	i := 0
-	for {
-		v.RLock()
-		ready := v.Ready
-		v.RUnlock()
-		if ready {
-			return nil
-		}
-
-		if i > 10 {
-			return fmt.Errorf("timeout waiting for voice")
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-		time.Sleep(1 * time.Second)
-		i++
+	if err := v.WaitReady(ctx); err != nil {
+		return fmt.Errorf("timeout waiting for voice")
 	}
+
+	return nil
 }
 
 // Open opens a voice connection.  This should be called
@@ -282,10 +530,12 @@ func (v *VoiceConnection) open() (err error) {
 	v.Lock()
 	defer v.Unlock()
 
-	// Don't open a websocket if one is already open
+	// A websocket which is still open belongs to the previous voice
+	// server, e.g. after a region change. Tear it down, and with it the
+	// udp connection and the goroutines using them.
 	if v.wsConn != nil {
-		v.log(LogWarning, "refusing to overwrite non-nil websocket")
-		return
+		v.log(LogInformational, "closing connection to previous voice endpoint")
+		v.closeConnections(false)
 	}
 
 	// TODO temp? loop to wait for the SessionID
@@ -346,23 +596,24 @@ func (v *VoiceConnection) wsListen(wsConn *websocket.Conn, close <-chan struct{}
 	v.log(LogInformational, "called")
 
 	for {
-		_, message, err := v.wsConn.ReadMessage()
+		_, message, err := wsConn.ReadMessage()
 		if err != nil {
-			// 4014 indicates a manual disconnection by someone in the guild;
-			// we shouldn't reconnect.
+			// 4014 indicates a manual disconnection by someone in the guild,
+			// or that the voice server changed; we shouldn't reconnect.
+			// A kick is confirmed by a VOICE_STATE_UPDATE without channel,
+			// a server change by a VOICE_SERVER_UPDATE which replaces this
+			// connection. If neither arrives in time, we were kicked.
 			if websocket.IsCloseError(err, 4014) {
-				v.log(LogInformational, "received 4014 manual disconnection")
-
-				// Abandon the voice WS connection
-				v.Lock()
-				v.wsConn = nil
-				v.Unlock()
+				v.log(LogInformational, "received 4014 disconnection")
 
-				v.session.Lock()
-				delete(v.session.VoiceConnections, v.GuildID)
-				v.session.Unlock()
-
-				v.Close()
+				time.AfterFunc(voiceServerUpdateTimeout, func() {
+					v.RLock()
+					sameConnection := v.wsConn == wsConn
+					v.RUnlock()
+					if sameConnection {
+						v.kicked()
+					}
+				})
 
 				return
 			}
@@ -449,29 +700,27 @@ func (v *VoiceConnection) onEvent(message []byte) {
 
 	case 4: // udp encryption secret key
 		v.Lock()
-		defer v.Unlock()
-
 		v.op4 = voiceOP4{}
-		if err := json.Unmarshal(e.RawData, &v.op4); err != nil {
+		err := json.Unmarshal(e.RawData, &v.op4)
+		v.Unlock()
+		if err != nil {
 			v.log(LogError, "OP4 unmarshall error, %s, %s", err, string(e.RawData))
 			return
 		}
+
+		// With the secret key the connection is able to send and
+		// receive audio.
+		v.setReady()
 		return
 
 	case 5:
-		if len(v.voiceSpeakingUpdateHandlers) == 0 {
-			return
-		}
-
 		voiceSpeakingUpdate := &VoiceSpeakingUpdate{}
 		if err := json.Unmarshal(e.RawData, voiceSpeakingUpdate); err != nil {
 			v.log(LogError, "OP5 unmarshall error, %s, %s", err, string(e.RawData))
 			return
 		}
 
-		for _, h := range v.voiceSpeakingUpdateHandlers {
-			h(v, voiceSpeakingUpdate)
-		}
+		v.handle(voiceSpeakingUpdate)
 
 	default:
 		v.log(LogDebug, "unknown voice operation, %d, %s", e.Operation, string(e.RawData))
@@ -679,11 +928,8 @@ func (v *VoiceConnection) opusSender(udpConn *net.UDPConn, close <-chan struct{}
 		return
 	}
 
-	// VoiceConnection is now ready to receive audio packets
-	// TODO: this needs reviewed as I think there must be a better way.
-	v.Lock()
-	v.Ready = true
-	v.Unlock()
+	// The connection is marked ready once the secret key arrives, but it
+	// can no longer send audio once the sender exits.
 	defer func() {
 		v.Lock()
 		v.Ready = false
@@ -870,7 +1116,11 @@ func (v *VoiceConnection) reconnect() {
 	v.reconnecting = true
 	v.Unlock()
 
-	defer func() { v.reconnecting = false }()
+	defer func() {
+		v.Lock()
+		v.reconnecting = false
+		v.Unlock()
+	}()
 
 	// Close any currently open connections
 	v.Close()
@@ -884,6 +1134,15 @@ func (v *VoiceConnection) reconnect() {
 			wait = 600
 		}
 
+		// Stop trying once the connection was disconnected or replaced.
+		v.session.RLock()
+		current := v.session.VoiceConnections[v.GuildID]
+		v.session.RUnlock()
+		if current != v {
+			v.log(LogInformational, "voice connection to guild %s was removed, stop reconnecting", v.GuildID)
+			return
+		}
+
 		if v.session.DataReady == false || v.session.wsConn == nil {
 			v.log(LogInformational, "cannot reconnect to channel %s with unready session", v.ChannelID)
 			continue
@@ -0,0 +1,209 @@
+package discordgo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestVoiceConnectionReadyEvents(t *testing.T) {
+	v := &VoiceConnection{}
+
+	var ready, resumed int
+	v.AddHandler(func(vc *VoiceConnection, e *VoiceReady) {
+		ready++
+	})
+	remove := v.AddHandler(func(vc *VoiceConnection, e *VoiceResumed) {
+		resumed++
+	})
+
+	v.setReady()
+	v.setReady()
+	remove()
+	v.setReady()
+
+	if ready != 1 {
+		t.Errorf("VoiceReady handler called %d times, want 1", ready)
+	}
+	if resumed != 1 {
+		t.Errorf("VoiceResumed handler called %d times, want 1", resumed)
+	}
+}
+
+func TestVoiceConnectionWaitReady(t *testing.T) {
+	v := &VoiceConnection{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := v.WaitReady(ctx); err != context.DeadlineExceeded {
+		t.Errorf("WaitReady on unready connection: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		v.setReady()
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := v.WaitReady(ctx); err != nil {
+		t.Errorf("WaitReady returned error: %v", err)
+	}
+}
+
+// newTestVoiceServer returns a voice server which sends its name to
+// handshakes for every handshake it receives, and then closes the
+// connection with closeCode, or keeps it open when closeCode is 0.
+func newTestVoiceServer(name string, handshakes chan<- string, closeCode int) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err == nil {
+			handshakes <- name
+		}
+		if closeCode != 0 {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, ""))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+// trustTestVoiceServers makes the websocket dialer trust the test voice
+// servers, and returns a func which restores it.
+func trustTestVoiceServers(servers ...*httptest.Server) func() {
+	certs := x509.NewCertPool()
+	for _, server := range servers {
+		certs.AddCert(server.Certificate())
+	}
+	defaultDialer := websocket.DefaultDialer
+	websocket.DefaultDialer = &websocket.Dialer{TLSClientConfig: &tls.Config{RootCAs: certs}}
+	return func() { websocket.DefaultDialer = defaultDialer }
+}
+
+// wantTestVoiceHandshake fails the test unless the next handshake is on
+// the voice server with the name.
+func wantTestVoiceHandshake(t *testing.T, handshakes <-chan string, name string) {
+	select {
+	case got := <-handshakes:
+		if got != name {
+			t.Fatalf("got handshake on voice server %s, want %s", got, name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no handshake on voice server %s", name)
+	}
+}
+
+func TestVoiceServerUpdateSwitchesEndpoint(t *testing.T) {
+	handshakes := make(chan string, 10)
+	serverA, serverB := newTestVoiceServer("a", handshakes, 0), newTestVoiceServer("b", handshakes, 0)
+	defer serverA.Close()
+	defer serverB.Close()
+	defer trustTestVoiceServers(serverA, serverB)()
+
+	endpointA := strings.TrimPrefix(serverA.URL, "https://")
+	endpointB := strings.TrimPrefix(serverB.URL, "https://")
+
+	s, _ := New("Bot token")
+	v := &VoiceConnection{GuildID: "g", session: s, sessionID: "session"}
+	s.VoiceConnections = map[string]*VoiceConnection{"g": v}
+	defer v.Close()
+
+	var changes []VoiceRegionChange
+	v.AddHandler(func(_ *VoiceConnection, e *VoiceRegionChange) { changes = append(changes, *e) })
+
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t", Endpoint: endpointA})
+	wantTestVoiceHandshake(t, handshakes, "a")
+
+	// The voice server goes away, and a new one is allocated.
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t"})
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t", Endpoint: endpointB})
+	wantTestVoiceHandshake(t, handshakes, "b")
+
+	want := []VoiceRegionChange{{OldEndpoint: endpointA, Endpoint: endpointB}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("got region changes %+v, want %+v", changes, want)
+	}
+
+	// open replaces a connection which is still open.
+	v.Lock()
+	v.endpoint = endpointA
+	v.Unlock()
+	if err := v.open(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	wantTestVoiceHandshake(t, handshakes, "a")
+}
+
+func TestVoiceServerChangeIsNotKick(t *testing.T) {
+	defer func(timeout time.Duration) { voiceServerUpdateTimeout = timeout }(voiceServerUpdateTimeout)
+	voiceServerUpdateTimeout = 200 * time.Millisecond
+
+	// Server a closes every connection with 4014, like Discord does when
+	// the voice server changes or the bot is kicked.
+	handshakes := make(chan string, 10)
+	serverA, serverB := newTestVoiceServer("a", handshakes, 4014), newTestVoiceServer("b", handshakes, 0)
+	defer serverA.Close()
+	defer serverB.Close()
+	defer trustTestVoiceServers(serverA, serverB)()
+
+	endpointA := strings.TrimPrefix(serverA.URL, "https://")
+	endpointB := strings.TrimPrefix(serverB.URL, "https://")
+
+	s, _ := New("Bot token")
+	v := &VoiceConnection{GuildID: "g", session: s, sessionID: "session"}
+	s.VoiceConnections = map[string]*VoiceConnection{"g": v}
+	defer v.Close()
+
+	kicked := make(chan struct{}, 1)
+	v.AddHandler(func(_ *VoiceConnection, e *VoiceKicked) { kicked <- struct{}{} })
+
+	// The server change arrives after the 4014 close.
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t", Endpoint: endpointA})
+	wantTestVoiceHandshake(t, handshakes, "a")
+	time.Sleep(50 * time.Millisecond)
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t", Endpoint: endpointB})
+	wantTestVoiceHandshake(t, handshakes, "b")
+
+	select {
+	case <-kicked:
+		t.Fatal("voice server change was reported as a kick")
+	case <-time.After(2 * voiceServerUpdateTimeout):
+	}
+	s.RLock()
+	_, exists := s.VoiceConnections["g"]
+	s.RUnlock()
+	if !exists {
+		t.Fatal("voice connection was removed by the voice server change")
+	}
+
+	// Without a server change, the 4014 close is a kick.
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "g", Token: "t", Endpoint: endpointA})
+	wantTestVoiceHandshake(t, handshakes, "a")
+	select {
+	case <-kicked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("4014 close without a voice server change was not reported as a kick")
+	}
+	s.RLock()
+	_, exists = s.VoiceConnections["g"]
+	s.RUnlock()
+	if exists {
+		t.Error("voice connection was not removed after the kick")
+	}
+}
@@ -0,0 +1,265 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains helpers to wait for events and to collect events
+// matching a filter.
+
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrInvalidEventFilter is returned by WaitFor and Collect when the filter
+// is not a func(*Session, *EventType) bool for an event type.
+var ErrInvalidEventFilter = errors.New("event filter must be a func(*Session, *EventType) bool")
+
+var sessionPtrType = reflect.TypeOf(&Session{})
+
+// filterHandler returns an event handler which calls fn with the events
+// accepted by filter, a func(*Session, *EventType) bool.
+func filterHandler(filter interface{}, fn func(event interface{})) (EventHandler, error) {
+	fv := reflect.ValueOf(filter)
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 2 || ft.In(0) != sessionPtrType ||
+		ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.Bool {
+		return nil, ErrInvalidEventFilter
+	}
+
+	handlerType := reflect.FuncOf([]reflect.Type{ft.In(0), ft.In(1)}, nil, false)
+	handler := reflect.MakeFunc(handlerType, func(args []reflect.Value) []reflect.Value {
+		if fv.Call(args)[0].Bool() {
+			fn(args[1].Interface())
+		}
+		return nil
+	})
+
+	eh := handlerForInterface(handler.Interface())
+	if eh == nil {
+		return nil, ErrInvalidEventFilter
+	}
+	return eh, nil
+}
+
+// WaitFor waits for the next event which is accepted by filter, and
+// returns it. It returns the context error if ctx is done before.
+// Do not wait in an event handler when SyncEvents is set or an ordered
+// EventDispatcher is used: the event is handled after the waiting handler
+// returns, so WaitFor blocks until ctx is done.
+// filter : A func(*Session, *EventType) bool for any event type, e.g.
+//
+//	e, err := s.WaitFor(ctx, func(s *discordgo.Session, m *discordgo.MessageCreate) bool {
+//		return m.ChannelID == channelID && m.Author.ID == userID
+//	})
+//	if err == nil {
+//		reply := e.(*discordgo.MessageCreate)
+//	}
+func (s *Session) WaitFor(ctx context.Context, filter interface{}) (interface{}, error) {
+	events := make(chan interface{}, 1)
+	eh, err := filterHandler(filter, func(event interface{}) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.addEventHandler(eh)()
+
+	select {
+	case event := <-events:
+		return event, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// CollectorOptions are the options of a Collector.
+type CollectorOptions struct {
+	// The number of events after which the collector stops, or 0 for no
+	// limit.
+	Max int
+
+	// The time after which the collector stops, or 0 for no limit.
+	Timeout time.Duration
+
+	// Called with every collected event, e.g. to respond to it.
+	OnCollect func(s *Session, event interface{})
+}
+
+// A Collector gathers the events matching a filter until it collected
+// CollectorOptions.Max events, CollectorOptions.Timeout passed, its
+// context is done or Stop is called. Its event handler is removed once it
+// is done.
+type Collector struct {
+	sync.Mutex
+
+	session *Session
+	options CollectorOptions
+	events  []interface{}
+	stopped bool
+	done    chan struct{}
+	remove  func()
+	once    sync.Once
+}
+
+// Collect starts a Collector for the events accepted by filter.
+// Like WaitFor, waiting for the collector in an event handler blocks until
+// ctx is done when SyncEvents is set or an ordered EventDispatcher is used.
+// ctx     : Stops the collector when done.
+// filter  : A func(*Session, *EventType) bool for any event type, see WaitFor.
+// options : The options of the collector, nil for none.
+func (s *Session) Collect(ctx context.Context, filter interface{}, options *CollectorOptions) (*Collector, error) {
+	c := &Collector{session: s, done: make(chan struct{})}
+	if options != nil {
+		c.options = *options
+	}
+
+	eh, err := filterHandler(filter, c.collect)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	c.remove = s.addEventHandler(eh)
+	c.Unlock()
+
+	if c.options.Timeout > 0 {
+		timer := time.AfterFunc(c.options.Timeout, c.Stop)
+		go func() {
+			<-c.done
+			timer.Stop()
+		}()
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Stop()
+		case <-c.done:
+		}
+	}()
+	return c, nil
+}
+
+// CollectMessages starts a Collector for the messages in a channel.
+// channelID : The ID of the channel.
+// filter    : Filters the messages, nil collects all.
+func (s *Session) CollectMessages(ctx context.Context, channelID string, filter func(*MessageCreate) bool, options *CollectorOptions) (*Collector, error) {
+	return s.Collect(ctx, func(_ *Session, m *MessageCreate) bool {
+		return m.ChannelID == channelID && (filter == nil || filter(m))
+	}, options)
+}
+
+// CollectReactions starts a Collector for the reactions added to a
+// message.
+// messageID : The ID of the message.
+// filter    : Filters the reactions, nil collects all.
+func (s *Session) CollectReactions(ctx context.Context, messageID string, filter func(*MessageReactionAdd) bool, options *CollectorOptions) (*Collector, error) {
+	return s.Collect(ctx, func(_ *Session, r *MessageReactionAdd) bool {
+		return r.MessageID == messageID && (filter == nil || filter(r))
+	}, options)
+}
+
+// CollectComponents starts a Collector for the component interactions,
+// like button clicks, on a message.
+// messageID : The ID of the message with the components.
+// filter    : Filters the interactions, nil collects all.
+func (s *Session) CollectComponents(ctx context.Context, messageID string, filter func(*InteractionCreate) bool, options *CollectorOptions) (*Collector, error) {
+	return s.Collect(ctx, func(_ *Session, i *InteractionCreate) bool {
+		return i.Type == InteractionMessageComponent && i.Message != nil && i.Message.ID == messageID &&
+			(filter == nil || filter(i))
+	}, options)
+}
+
+func (c *Collector) collect(event interface{}) {
+	c.Lock()
+	if c.stopped {
+		c.Unlock()
+		return
+	}
+	c.events = append(c.events, event)
+	full := c.options.Max > 0 && len(c.events) >= c.options.Max
+	if full {
+		// Handlers run concurrently, so stop collecting right away.
+		c.stopped = true
+	}
+	c.Unlock()
+
+	if c.options.OnCollect != nil {
+		c.options.OnCollect(c.session, event)
+	}
+	if full {
+		c.Stop()
+	}
+}
+
+// Stop stops the collector and removes its event handler.
+func (c *Collector) Stop() {
+	c.once.Do(func() {
+		c.Lock()
+		c.stopped = true
+		remove := c.remove
+		c.Unlock()
+
+		remove()
+		close(c.done)
+	})
+}
+
+// Done returns a channel which is closed when the collector stopped.
+func (c *Collector) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait waits for the collector to stop and returns the collected events.
+func (c *Collector) Wait() []interface{} {
+	<-c.done
+	return c.Events()
+}
+
+// Events returns the events collected so far.
+func (c *Collector) Events() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+
+	return append([]interface{}(nil), c.events...)
+}
+
+// Messages returns the collected MessageCreate events.
+func (c *Collector) Messages() (messages []*MessageCreate) {
+	for _, e := range c.Events() {
+		if m, ok := e.(*MessageCreate); ok {
+			messages = append(messages, m)
+		}
+	}
+	return
+}
+
+// Reactions returns the collected MessageReactionAdd events.
+func (c *Collector) Reactions() (reactions []*MessageReactionAdd) {
+	for _, e := range c.Events() {
+		if r, ok := e.(*MessageReactionAdd); ok {
+			reactions = append(reactions, r)
+		}
+	}
+	return
+}
+
+// Interactions returns the collected InteractionCreate events.
+func (c *Collector) Interactions() (interactions []*InteractionCreate) {
+	for _, e := range c.Events() {
+		if i, ok := e.(*InteractionCreate); ok {
+			interactions = append(interactions, i)
+		}
+	}
+	return
+}
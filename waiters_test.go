@@ -0,0 +1,157 @@
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+
+	go func() {
+		for s.handlerCount(messageCreateEventType) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: "1", ChannelID: "a"}})
+		s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: "2", ChannelID: "b"}})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	e, err := s.WaitFor(ctx, func(_ *Session, m *MessageCreate) bool { return m.ChannelID == "b" })
+	if err != nil {
+		t.Fatalf("WaitFor: %v", err)
+	}
+	if m, ok := e.(*MessageCreate); !ok || m.ID != "2" {
+		t.Errorf("got event %#v, want message 2", e)
+	}
+	if n := s.handlerCount(messageCreateEventType); n != 0 {
+		t.Errorf("got %d handlers after WaitFor returned, want 0", n)
+	}
+
+	if _, err := s.WaitFor(ctx, func(m *MessageCreate) bool { return true }); !errors.Is(err, ErrInvalidEventFilter) {
+		t.Errorf("invalid filter: got error %v, want %v", err, ErrInvalidEventFilter)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.WaitFor(ctx, func(_ *Session, m *MessageCreate) bool { return true }); err != context.Canceled {
+		t.Errorf("canceled context: got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCollectReactionsMax(t *testing.T) {
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+
+	var collected int
+	c, err := s.CollectReactions(context.Background(), "m", func(r *MessageReactionAdd) bool {
+		return r.UserID != "bot"
+	}, &CollectorOptions{Max: 2, OnCollect: func(*Session, interface{}) { collected++ }})
+	if err != nil {
+		t.Fatalf("CollectReactions: %v", err)
+	}
+
+	for _, r := range []*MessageReaction{
+		{MessageID: "m", UserID: "bot"},
+		{MessageID: "other", UserID: "u1"},
+		{MessageID: "m", UserID: "u1"},
+		{MessageID: "m", UserID: "u2"},
+		{MessageID: "m", UserID: "u3"},
+	} {
+		s.handleEvent(messageReactionAddEventType, &MessageReactionAdd{r})
+	}
+
+	reactions := c.Wait()
+	if len(reactions) != 2 || collected != 2 {
+		t.Fatalf("got %d reactions and %d OnCollect calls, want 2", len(reactions), collected)
+	}
+	if r := c.Reactions(); r[0].UserID != "u1" || r[1].UserID != "u2" {
+		t.Errorf("got reactions of %q and %q, want u1 and u2", r[0].UserID, r[1].UserID)
+	}
+	if n := s.handlerCount(messageReactionAddEventType); n != 0 {
+		t.Errorf("got %d handlers after the collector stopped, want 0", n)
+	}
+}
+
+func TestCollectMessagesMaxAsync(t *testing.T) {
+	s, _ := New("Bot token")
+
+	var collected int32
+	c, err := s.CollectMessages(context.Background(), "a", nil, &CollectorOptions{
+		Max: 5,
+		OnCollect: func(*Session, interface{}) {
+			atomic.AddInt32(&collected, 1)
+			time.Sleep(time.Millisecond)
+		},
+	})
+	if err != nil {
+		t.Fatalf("CollectMessages: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ChannelID: "a"}})
+	}
+
+	if n := len(c.Wait()); n != 5 {
+		t.Errorf("got %d messages, want 5", n)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := atomic.LoadInt32(&collected); n != 5 {
+		t.Errorf("got %d OnCollect calls, want 5", n)
+	}
+}
+
+func TestCollectComponentsStop(t *testing.T) {
+	s, _ := New("Bot token")
+	s.SyncEvents = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := s.CollectComponents(ctx, "m", nil, nil)
+	if err != nil {
+		t.Fatalf("CollectComponents: %v", err)
+	}
+
+	s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{Type: InteractionApplicationCommand}})
+	s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{Type: InteractionMessageComponent, Message: &Message{ID: "m"}}})
+	cancel()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("collector did not stop when its context was canceled")
+	}
+	if n := len(c.Interactions()); n != 1 {
+		t.Errorf("got %d interactions, want 1", n)
+	}
+}
+
+func TestCollectMessagesTimeout(t *testing.T) {
+	s, _ := New("Bot token")
+
+	c, err := s.CollectMessages(context.Background(), "a", nil, &CollectorOptions{Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("CollectMessages: %v", err)
+	}
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("collector did not stop after its timeout")
+	}
+	if n := s.handlerCount(messageCreateEventType); n != 0 {
+		t.Errorf("got %d handlers after the collector stopped, want 0", n)
+	}
+}
+
+// handlerCount returns the number of handlers of an event type.
+func (s *Session) handlerCount(t string) int {
+	s.handlersMu.RLock()
+	defer s.handlersMu.RUnlock()
+
+	return len(s.handlers[t]) + len(s.onceHandlers[t])
+}
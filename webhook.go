@@ -35,6 +35,7 @@ type WebhookParams struct {
 	Components      []MessageComponent      `json:"components"`
 	Embeds          []*MessageEmbed         `json:"embeds,omitempty"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+	Attachments     []*MessageAttachment    `json:"attachments,omitempty"`
 	// NOTE: Works only for followup messages.
 	Flags uint64 `json:"flags,omitempty"`
 }
@@ -46,4 +47,8 @@ type WebhookEdit struct {
 	Embeds          []*MessageEmbed         `json:"embeds,omitempty"`
 	Files           []*File                 `json:"-"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Attachments lists the existing attachments to keep, see
+	// MessageEdit.Attachments.
+	Attachments *[]*MessageAttachment `json:"attachments,omitempty"`
 }
@@ -13,11 +13,15 @@ package discordgo
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -36,6 +40,10 @@ var ErrWSNotFound = errors.New("no websocket connection exists")
 // more than the total shard count
 var ErrWSShardBounds = errors.New("ShardID must be less than ShardCount")
 
+// ErrTooManyUserIDs is returned when more than 100 members are requested
+// by ID from the gateway.
+var ErrTooManyUserIDs = errors.New("at most 100 user IDs can be requested at once")
+
 type resumePacket struct {
 	Op   int `json:"op"`
 	Data struct {
@@ -73,14 +81,34 @@ func (s *Session) Open() error {
 		s.gateway = s.gateway + "?v=" + APIVersion + "&encoding=json"
 	}
 
+	// Sessions are resumed on the Gateway given in the READY packet.
+	s.gatewaySessionMu.RLock()
+	sessionID, resumeGatewayURL := s.sessionID, s.resumeGatewayURL
+	s.gatewaySessionMu.RUnlock()
+	sequence := atomic.LoadInt64(s.sequence)
+	resume := sessionID != "" || sequence != 0
+	gateway := s.gateway
+	if resume && resumeGatewayURL != "" {
+		gateway = resumeGatewayURL + "?v=" + APIVersion + "&encoding=json"
+	}
+
+	if !resume {
+		if err = s.waitToIdentify(); err != nil {
+			return err
+		}
+	}
+
 	// Connect to the Gateway
-	s.log(LogInformational, "connecting to gateway %s", s.gateway)
+	s.log(LogInformational, "connecting to gateway %s", gateway)
 	header := http.Header{}
 	header.Add("accept-encoding", "zlib")
-	s.wsConn, _, err = websocket.DefaultDialer.Dial(s.gateway, header)
+	s.wsConn, _, err = websocket.DefaultDialer.Dial(gateway, header)
 	if err != nil {
-		s.log(LogError, "error connecting to gateway %s, %s", s.gateway, err)
+		s.log(LogError, "error connecting to gateway %s, %s", gateway, err)
 		s.gateway = "" // clear cached gateway
+		s.gatewaySessionMu.Lock()
+		s.resumeGatewayURL = "" // resume on the cached gateway next time
+		s.gatewaySessionMu.Unlock()
 		s.wsConn = nil // Just to be safe.
 		return err
 	}
@@ -89,6 +117,9 @@ func (s *Session) Open() error {
 		return nil
 	})
 
+	// The gateway rate limit applies per connection.
+	s.gatewayLimiter.reset()
+
 	defer func() {
 		// because of this, all code below must set err to the error
 		// when exiting with an error :)  Maybe someone has a better
@@ -103,6 +134,7 @@ func (s *Session) Open() error {
 	// When processed by onEvent the heartbeat goroutine will be started.
 	mt, m, err := s.wsConn.ReadMessage()
 	if err != nil {
+		err = gatewayCloseError(err)
 		return err
 	}
 	e, err := s.onEvent(mt, m)
@@ -123,8 +155,7 @@ func (s *Session) Open() error {
 
 	// Now we send either an Op 2 Identity if this is a brand new
 	// connection or Op 6 Resume if we are resuming an existing connection.
-	sequence := atomic.LoadInt64(s.sequence)
-	if s.sessionID == "" && sequence == 0 {
+	if !resume {
 
 		// Send Op 2 Identity Packet
 		err = s.identify()
@@ -139,13 +170,11 @@ func (s *Session) Open() error {
 		p := resumePacket{}
 		p.Op = 6
 		p.Data.Token = s.Token
-		p.Data.SessionID = s.sessionID
+		p.Data.SessionID = sessionID
 		p.Data.Sequence = sequence
 
 		s.log(LogInformational, "sending resume packet to gateway")
-		s.wsMutex.Lock()
-		err = s.wsConn.WriteJSON(p)
-		s.wsMutex.Unlock()
+		err = s.gatewayWritePriority(s.wsConn, p)
 		if err != nil {
 			err = fmt.Errorf("error sending gateway resume packet, %s, %s", s.gateway, err)
 			return err
@@ -170,6 +199,7 @@ func (s *Session) Open() error {
 	// Now Discord should send us a READY or RESUMED packet.
 	mt, m, err = s.wsConn.ReadMessage()
 	if err != nil {
+		err = gatewayCloseError(err)
 		return err
 	}
 	e, err = s.onEvent(mt, m)
@@ -226,16 +256,11 @@ func (s *Session) listen(wsConn *websocket.Conn, listening <-chan interface{}) {
 
 			if sameConnection {
 
+				err = gatewayCloseError(err)
 				s.log(LogWarning, "error reading from gateway %s websocket, %s", s.gateway, err)
 				// There has been an error reading, close the websocket so that
-				// OnDisconnect event is emitted.
-				err := s.Close()
-				if err != nil {
-					s.log(LogWarning, "error closing session connection, %s", err)
-				}
-
-				s.log(LogInformational, "calling reconnect() now")
-				s.reconnect()
+				// OnDisconnect event is emitted, and reconnect if possible.
+				s.handleGatewayClose(err)
 			}
 
 			return
@@ -247,7 +272,10 @@ func (s *Session) listen(wsConn *websocket.Conn, listening <-chan interface{}) {
 			return
 
 		default:
-			s.onEvent(messageType, message)
+			if _, err = s.onEvent(messageType, message); err == errInvalidSessionResumable {
+				s.handleGatewayClose(err)
+				return
+			}
 
 		}
 	}
@@ -293,17 +321,24 @@ func (s *Session) heartbeat(wsConn *websocket.Conn, listening <-chan interface{}
 		s.RUnlock()
 		sequence := atomic.LoadInt64(s.sequence)
 		s.log(LogDebug, "sending gateway websocket heartbeat seq %d", sequence)
-		s.wsMutex.Lock()
 		s.LastHeartbeatSent = time.Now().UTC()
-		err = wsConn.WriteJSON(heartbeatOp{1, sequence})
-		s.wsMutex.Unlock()
+		err = s.gatewayWritePriority(wsConn, heartbeatOp{1, sequence})
 		if err != nil || time.Now().UTC().Sub(last) > (heartbeatIntervalMsec*FailedHeartbeatAcks) {
+			// Do not reconnect if the connection was closed in the meantime,
+			// e.g. after a fatal close code.
+			s.RLock()
+			sameConnection := s.wsConn == wsConn
+			s.RUnlock()
+			if !sameConnection {
+				return
+			}
+
 			if err != nil {
 				s.log(LogError, "error sending heartbeat to gateway %s, %s", s.gateway, err)
 			} else {
 				s.log(LogError, "haven't gotten a heartbeat ACK in %v, triggering a reconnection", time.Now().UTC().Sub(last))
 			}
-			s.Close()
+			s.CloseWithCode(websocket.CloseServiceRestart)
 			s.reconnect()
 			return
 		}
@@ -395,24 +430,18 @@ func (s *Session) UpdateStatusComplex(usd UpdateStatusData) (err error) {
 		usd.Activities = make([]*Activity, 0)
 	}
 
-	s.RLock()
-	defer s.RUnlock()
-	if s.wsConn == nil {
-		return ErrWSNotFound
-	}
-
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(updateStatusOp{3, usd})
-	s.wsMutex.Unlock()
+	err = s.gatewayWrite(updateStatusOp{3, usd})
 
 	return
 }
 
 type requestGuildMembersData struct {
 	GuildIDs  []string `json:"guild_id"`
-	Query     string   `json:"query"`
+	Query     *string  `json:"query,omitempty"`
 	Limit     int      `json:"limit"`
 	Presences bool     `json:"presences"`
+	UserIDs   []string `json:"user_ids,omitempty"`
+	Nonce     string   `json:"nonce,omitempty"`
 }
 
 type requestGuildMembersOp struct {
@@ -429,7 +458,7 @@ type requestGuildMembersOp struct {
 func (s *Session) RequestGuildMembers(guildID string, query string, limit int, presences bool) (err error) {
 	data := requestGuildMembersData{
 		GuildIDs:  []string{guildID},
-		Query:     query,
+		Query:     &query,
 		Limit:     limit,
 		Presences: presences,
 	}
@@ -446,7 +475,7 @@ func (s *Session) RequestGuildMembers(guildID string, query string, limit int, p
 func (s *Session) RequestGuildMembersBatch(guildIDs []string, query string, limit int, presences bool) (err error) {
 	data := requestGuildMembersData{
 		GuildIDs:  guildIDs,
-		Query:     query,
+		Query:     &query,
 		Limit:     limit,
 		Presences: presences,
 	}
@@ -457,19 +486,181 @@ func (s *Session) RequestGuildMembersBatch(guildIDs []string, query string, limi
 func (s *Session) requestGuildMembers(data requestGuildMembersData) (err error) {
 	s.log(LogInformational, "called")
 
-	s.RLock()
-	defer s.RUnlock()
-	if s.wsConn == nil {
-		return ErrWSNotFound
-	}
+	err = s.gatewayWrite(requestGuildMembersOp{8, data})
+
+	return
+}
+
+// RequestGuildMembersParams are the parameters of a guild members request
+// sent with RequestGuildMembersComplex or RequestGuildMembersStream.
+type RequestGuildMembersParams struct {
+	GuildID string
 
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(requestGuildMembersOp{8, data})
-	s.wsMutex.Unlock()
+	// The prefix of the usernames to return, leave empty with no UserIDs
+	// to return all members.
+	Query string
+
+	// The IDs of the members to return instead of a query, at most 100.
+	UserIDs []string
+
+	// Max number of members to return for a query, or 0 for all.
+	Limit int
+
+	// Whether to return the presences of the members.
+	Presences bool
+}
 
+// GuildMembersResult are the members returned by RequestGuildMembersComplex.
+type GuildMembersResult struct {
+	Members   []*Member
+	Presences []*Presence
+
+	// The requested user IDs which are not members of the guild.
+	NotFound []string
+}
+
+// RequestGuildMembersComplex requests guild members from the gateway and
+// waits for all GuildMembersChunk events of the request, matched by a
+// nonce, to return the members they contain. It returns the context error
+// if ctx is done before all chunks arrived.
+func (s *Session) RequestGuildMembersComplex(ctx context.Context, params *RequestGuildMembersParams) (result *GuildMembersResult, err error) {
+	result = &GuildMembersResult{}
+	notFound := make(map[string]bool)
+	err = s.RequestGuildMembersStream(ctx, params, func(c *GuildMembersChunk) {
+		result.Members = append(result.Members, c.Members...)
+		result.Presences = append(result.Presences, c.Presences...)
+
+		// Every chunk lists the user IDs which were not found.
+		for _, id := range c.NotFound {
+			if !notFound[id] {
+				notFound[id] = true
+				result.NotFound = append(result.NotFound, id)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
 	return
 }
 
+// RequestGuildMembersStream requests guild members from the gateway and
+// calls fn with each GuildMembersChunk event of the request, matched by a
+// nonce, until all chunks arrived. fn is called from a single goroutine.
+// It returns the context error if ctx is done before all chunks arrived,
+// and ErrTooManyUserIDs for more than 100 UserIDs.
+// The chunks are delivered before the event handlers run, so it may be
+// called from an event handler, but not when SyncEvents is set: the
+// gateway is not read while a handler runs, so it blocks until ctx is done.
+func (s *Session) RequestGuildMembersStream(ctx context.Context, params *RequestGuildMembersParams, fn func(*GuildMembersChunk)) error {
+	if len(params.UserIDs) > 100 {
+		return ErrTooManyUserIDs
+	}
+
+	nonce, err := newGatewayNonce()
+	if err != nil {
+		return err
+	}
+
+	data := requestGuildMembersData{
+		GuildIDs:  []string{params.GuildID},
+		Limit:     params.Limit,
+		Presences: params.Presences,
+		UserIDs:   params.UserIDs,
+		Nonce:     nonce,
+	}
+	if len(params.UserIDs) == 0 {
+		data.Query = &params.Query
+	}
+
+	req := &guildMembersRequest{notify: make(chan struct{}, 1)}
+	s.guildMembersRequestsMu.Lock()
+	if s.guildMembersRequests == nil {
+		s.guildMembersRequests = make(map[string]*guildMembersRequest)
+	}
+	s.guildMembersRequests[nonce] = req
+	s.guildMembersRequestsMu.Unlock()
+	defer func() {
+		s.guildMembersRequestsMu.Lock()
+		delete(s.guildMembersRequests, nonce)
+		s.guildMembersRequestsMu.Unlock()
+	}()
+
+	if err = s.requestGuildMembers(data); err != nil {
+		return err
+	}
+
+	received := make(map[int]bool)
+	for {
+		select {
+		case <-req.notify:
+			for _, c := range req.take() {
+				if received[c.ChunkIndex] {
+					continue
+				}
+				received[c.ChunkIndex] = true
+				fn(c)
+				if len(received) >= c.ChunkCount {
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// guildMembersRequest holds the chunks received for a
+// RequestGuildMembersStream call until it handles them.
+type guildMembersRequest struct {
+	sync.Mutex
+	chunks []*GuildMembersChunk
+
+	// Signaled when chunks were added.
+	notify chan struct{}
+}
+
+// take returns and forgets the received chunks.
+func (r *guildMembersRequest) take() []*GuildMembersChunk {
+	r.Lock()
+	defer r.Unlock()
+
+	chunks := r.chunks
+	r.chunks = nil
+	return chunks
+}
+
+// onGuildMembersChunk passes a chunk to the RequestGuildMembersStream call
+// with its nonce. It does not block, as it is called before the handlers
+// of the event are dispatched.
+func (s *Session) onGuildMembersChunk(c *GuildMembersChunk) {
+	s.guildMembersRequestsMu.Lock()
+	req, ok := s.guildMembersRequests[c.Nonce]
+	s.guildMembersRequestsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	req.Lock()
+	req.chunks = append(req.chunks, c)
+	req.Unlock()
+
+	select {
+	case req.notify <- struct{}{}:
+	default:
+	}
+}
+
+// newGatewayNonce returns a random nonce to match gateway requests with
+// their responses.
+func newGatewayNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // onEvent is the "event handler" for all messages received on the
 // Discord Gateway API websocket connection.
 //
@@ -513,13 +704,15 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 
 	s.log(LogDebug, "Op: %d, Seq: %d, Type: %s, Data: %s\n\n", e.Operation, e.Sequence, e.Type, string(e.RawData))
 
+	if s.Recorder != nil {
+		s.Recorder.record(s, e)
+	}
+
 	// Ping request.
 	// Must respond with a heartbeat packet within 5 seconds
 	if e.Operation == 1 {
 		s.log(LogInformational, "sending heartbeat in response to Op1")
-		s.wsMutex.Lock()
-		err = s.wsConn.WriteJSON(heartbeatOp{1, atomic.LoadInt64(s.sequence)})
-		s.wsMutex.Unlock()
+		err = s.gatewayWritePriority(s.wsConn, heartbeatOp{1, atomic.LoadInt64(s.sequence)})
 		if err != nil {
 			s.log(LogError, "error sending heartbeat in response to Op1")
 			return e, err
@@ -538,11 +731,26 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 	}
 
 	// Invalid Session
-	// Must respond with a Identify packet.
+	// Must reconnect and resume if the session is resumable, otherwise
+	// respond with a Identify packet.
 	if e.Operation == 9 {
 
+		var resumable bool
+		if err = json.Unmarshal(e.RawData, &resumable); err != nil {
+			s.log(LogWarning, "error unmarshalling Op9 resumable flag, %s", err)
+		}
+		if resumable {
+			s.log(LogInformational, "resuming in response to Op9")
+			return e, errInvalidSessionResumable
+		}
+
 		s.log(LogInformational, "sending identify packet to gateway in response to Op9")
+		s.resetSession()
 
+		if err = s.waitToIdentify(); err != nil {
+			s.log(LogWarning, "error waiting to identify, %s", err)
+			return e, err
+		}
 		err = s.identify()
 		if err != nil {
 			s.log(LogWarning, "error sending gateway identify packet, %s, %s", s.gateway, err)
@@ -576,12 +784,20 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 	// Store the message sequence
 	atomic.StoreInt64(s.sequence, e.Sequence)
 
+	s.dispatchEvent(e)
+
+	return e, nil
+}
+
+// dispatchEvent decodes a dispatch event and passes it to the state and
+// the event handlers.
+func (s *Session) dispatchEvent(e *Event) {
 	// Map event to registered event handlers and pass it along to any registered handlers.
 	if eh, ok := registeredInterfaceProviders[e.Type]; ok {
 		e.Struct = eh.New()
 
 		// Attempt to unmarshal our event.
-		if err = json.Unmarshal(e.RawData, e.Struct); err != nil {
+		if err := json.Unmarshal(e.RawData, e.Struct); err != nil {
 			s.log(LogError, "error unmarshalling %s event, %s", e.Type, err)
 		}
 
@@ -599,8 +815,6 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 
 	// For legacy reasons, we send the raw event also, this could be useful for handling unknown events.
 	s.handleEvent(eventEventType, e)
-
-	return e, nil
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -685,20 +899,13 @@ func (s *Session) ChannelVoiceJoinManual(gID, cID string, mute, deaf bool) (err
 
 	// Send the request to Discord that we want to join the voice channel
 	data := voiceChannelJoinOp{4, voiceChannelJoinData{&gID, channelID, mute, deaf}}
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(data)
-	s.wsMutex.Unlock()
+	err = s.gatewayWrite(data)
 	return
 }
 
 // onVoiceStateUpdate handles Voice State Update events on the data websocket.
 func (s *Session) onVoiceStateUpdate(st *VoiceStateUpdate) {
 
-	// If we don't have a connection for the channel, don't bother
-	if st.ChannelID == "" {
-		return
-	}
-
 	// Check if we have a voice connection to update
 	s.RLock()
 	voice, exists := s.VoiceConnections[st.GuildID]
@@ -712,12 +919,25 @@ func (s *Session) onVoiceStateUpdate(st *VoiceStateUpdate) {
 		return
 	}
 
+	// An empty channel while we still hold a connection means someone
+	// else disconnected us. Disconnect removes the connection before it
+	// sends the OP4, so a requested disconnect never gets here.
+	if st.ChannelID == "" {
+		voice.kicked()
+		return
+	}
+
 	// Store the SessionID for later use.
 	voice.Lock()
+	oldChannelID := voice.ChannelID
 	voice.UserID = st.UserID
 	voice.sessionID = st.SessionID
 	voice.ChannelID = st.ChannelID
 	voice.Unlock()
+
+	if oldChannelID != "" && oldChannelID != st.ChannelID {
+		voice.handle(&VoiceChannelMove{OldChannelID: oldChannelID, ChannelID: st.ChannelID})
+	}
 }
 
 // onVoiceServerUpdate handles the Voice Server Update data websocket event.
@@ -738,17 +958,35 @@ func (s *Session) onVoiceServerUpdate(st *VoiceServerUpdate) {
 		return
 	}
 
+	// Updates are handled in their own goroutines, handle one at a time.
+	voice.serverUpdateMu.Lock()
+	defer voice.serverUpdateMu.Unlock()
+
 	// If currently connected to voice ws/udp, then disconnect.
 	// Has no effect if not connected.
 	voice.Close()
 
+	// A null endpoint means the voice server went away and Discord is
+	// allocating a new one, which will arrive in another update. The old
+	// endpoint is kept to detect the region change.
+	if st.Endpoint == "" {
+		s.log(LogInformational, "voice server for guild %s is being reallocated", st.GuildID)
+		return
+	}
+
 	// Store values for later use
 	voice.Lock()
+	oldEndpoint := voice.endpoint
 	voice.token = st.Token
 	voice.endpoint = st.Endpoint
 	voice.GuildID = st.GuildID
 	voice.Unlock()
 
+	if oldEndpoint != "" && oldEndpoint != st.Endpoint {
+		voice.handle(&VoiceRegionChange{OldEndpoint: oldEndpoint, Endpoint: st.Endpoint})
+	}
+	voice.handle(&VoiceConnecting{Endpoint: st.Endpoint})
+
 	// Open a connection to the voice server
 	err := voice.open()
 	if err != nil {
@@ -792,11 +1030,27 @@ func (s *Session) identify() error {
 	// Send Identify packet to Discord
 	op := identifyOp{2, s.Identify}
 	s.log(LogDebug, "Identify Packet: \n%#v", op)
-	s.wsMutex.Lock()
-	err := s.wsConn.WriteJSON(op)
-	s.wsMutex.Unlock()
 
-	return err
+	return s.gatewayWritePriority(s.wsConn, op)
+}
+
+// waitToIdentify waits for the turn of the session to identify amongst the
+// sessions of the bot, or until the session is closed. It is called before
+// connecting, as Discord closes a connection which does not identify or
+// heartbeat in time.
+func (s *Session) waitToIdentify() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.identifyMu.Lock()
+	s.identifyCancel = cancel
+	s.identifyMu.Unlock()
+	defer func() {
+		s.identifyMu.Lock()
+		s.identifyCancel = nil
+		s.identifyMu.Unlock()
+		cancel()
+	}()
+
+	return s.identifyLimiter().Wait(ctx, s.ShardID)
 }
 
 func (s *Session) reconnect() {
@@ -809,6 +1063,10 @@ func (s *Session) reconnect() {
 
 		wait := time.Duration(1)
 
+		s.gatewaySessionMu.RLock()
+		sessionID := s.sessionID
+		s.gatewaySessionMu.RUnlock()
+
 		for {
 			s.log(LogInformational, "trying to reconnect to gateway")
 
@@ -816,23 +1074,11 @@ func (s *Session) reconnect() {
 			if err == nil {
 				s.log(LogInformational, "successfully reconnected to gateway")
 
-				// I'm not sure if this is actually needed.
-				// if the gw reconnect works properly, voice should stay alive
-				// However, there seems to be cases where something "weird"
-				// happens.  So we're doing this for now just to improve
-				// stability in those edge cases.
-				s.RLock()
-				defer s.RUnlock()
-				for _, v := range s.VoiceConnections {
+				s.gatewaySessionMu.RLock()
+				resumed := sessionID != "" && s.sessionID == sessionID
+				s.gatewaySessionMu.RUnlock()
 
-					s.log(LogInformational, "reconnecting voice connection to guild %s", v.GuildID)
-					go v.reconnect()
-
-					// This is here just to prevent violently spamming the
-					// voice reconnects
-					time.Sleep(1 * time.Second)
-
-				}
+				go s.reconnectVoice(resumed)
 				return
 			}
 
@@ -845,6 +1091,16 @@ func (s *Session) reconnect() {
 
 			s.log(LogError, "error reconnecting to gateway, %s", err)
 
+			switch gatewayCloseAction(err) {
+			case GatewayCloseFatal:
+				s.log(LogError, "not reconnecting to gateway after fatal error")
+				s.handleEvent(gatewayFatalErrorEventType, &GatewayFatalError{err.(*GatewayCloseError)})
+				return
+			case GatewayCloseReidentify:
+				s.resetSession()
+				sessionID = ""
+			}
+
 			<-time.After(wait * time.Second)
 			wait *= 2
 			if wait > 600 {
@@ -854,6 +1110,37 @@ func (s *Session) reconnect() {
 	}
 }
 
+// reconnectVoice reconnects the voice connections that did not survive a
+// gateway reconnect. When the gateway session was resumed, Discord keeps
+// the voice sessions alive and only connections that are not ready are
+// reconnected, after a new identify every connection has to rejoin.
+func (s *Session) reconnectVoice(resumed bool) {
+
+	s.RLock()
+	voices := make([]*VoiceConnection, 0, len(s.VoiceConnections))
+	for _, v := range s.VoiceConnections {
+		voices = append(voices, v)
+	}
+	s.RUnlock()
+
+	for _, v := range voices {
+
+		v.RLock()
+		ready := v.Ready
+		v.RUnlock()
+		if resumed && ready {
+			continue
+		}
+
+		s.log(LogInformational, "reconnecting voice connection to guild %s", v.GuildID)
+		go v.reconnect()
+
+		// This is here just to prevent violently spamming the
+		// voice reconnects
+		time.Sleep(1 * time.Second)
+	}
+}
+
 // Close closes a websocket and stops all listening/heartbeat goroutines.
 // TODO: Add support for Voice WS/UDP
 func (s *Session) Close() error {
@@ -866,6 +1153,14 @@ func (s *Session) Close() error {
 func (s *Session) CloseWithCode(closeCode int) (err error) {
 
 	s.log(LogInformational, "called")
+
+	// Open holds the lock while it waits to identify, stop waiting first.
+	s.identifyMu.Lock()
+	if s.identifyCancel != nil {
+		s.identifyCancel()
+	}
+	s.identifyMu.Unlock()
+
 	s.Lock()
 
 	s.DataReady = false
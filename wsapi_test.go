@@ -0,0 +1,138 @@
+package discordgo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newGuildMembersTestSession returns an open Session connected to a test
+// gateway, which answers every guild members request with two chunks and
+// sends the request to requests, and a func to close both.
+func newGuildMembersTestSession(t *testing.T, requests chan<- requestGuildMembersData) (*Session, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]interface{}{"op": 10, "d": map[string]interface{}{"heartbeat_interval": 45000}})
+		conn.ReadMessage()
+		conn.WriteJSON(map[string]interface{}{"op": 0, "s": 1, "t": "READY", "d": map[string]interface{}{"session_id": "session"}})
+
+		for {
+			var op struct {
+				Op   int             `json:"op"`
+				Data json.RawMessage `json:"d"`
+			}
+			if err := conn.ReadJSON(&op); err != nil {
+				return
+			}
+			var data requestGuildMembersData
+			if op.Op != 8 || json.Unmarshal(op.Data, &data) != nil {
+				continue
+			}
+			select {
+			case requests <- data:
+			default:
+			}
+
+			chunk := func(index int, nonce string, members ...string) {
+				var ms []map[string]interface{}
+				for _, id := range members {
+					ms = append(ms, map[string]interface{}{"user": map[string]interface{}{"id": id}})
+				}
+				conn.WriteJSON(map[string]interface{}{"op": 0, "s": 2, "t": "GUILD_MEMBERS_CHUNK", "d": map[string]interface{}{
+					"guild_id": "guild", "chunk_index": index, "chunk_count": 2, "nonce": nonce,
+					"members": ms, "not_found": []string{"missing"},
+				}})
+			}
+			chunk(0, "other", "stranger")
+			chunk(1, data.Nonce, "b")
+			chunk(0, data.Nonce, "a")
+		}
+	}))
+
+	s, _ := New("Bot token")
+	s.gateway = "ws" + strings.TrimPrefix(server.URL, "http")
+	s.IdentifyLimiter = NewIdentifyLimiter(SessionInformation{})
+	if err := s.Open(); err != nil {
+		server.Close()
+		t.Fatalf("Open returned error: %v", err)
+	}
+	return s, func() {
+		s.Close()
+		server.Close()
+	}
+}
+
+func TestRequestGuildMembersComplex(t *testing.T) {
+	requests := make(chan requestGuildMembersData, 1)
+	s, closeSession := newGuildMembersTestSession(t, requests)
+	defer closeSession()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := s.RequestGuildMembersComplex(ctx, &RequestGuildMembersParams{GuildID: "guild", UserIDs: []string{"a", "b", "missing"}})
+	if err != nil {
+		t.Fatalf("RequestGuildMembersComplex returned error: %v", err)
+	}
+
+	if len(result.Members) != 2 {
+		t.Errorf("got %d members, want 2", len(result.Members))
+	}
+	if len(result.NotFound) != 1 || result.NotFound[0] != "missing" {
+		t.Errorf("got not found %q, want [missing]", result.NotFound)
+	}
+	for _, m := range result.Members {
+		if m.User.ID == "stranger" {
+			t.Errorf("got member of a chunk with another nonce")
+		}
+	}
+
+	req := <-requests
+	if req.Query != nil || len(req.UserIDs) != 3 || req.Nonce == "" {
+		t.Errorf("got request %+v, want user IDs and a nonce without a query", req)
+	}
+
+	_, err = s.RequestGuildMembersComplex(ctx, &RequestGuildMembersParams{GuildID: "guild", UserIDs: make([]string, 101)})
+	if err != ErrTooManyUserIDs {
+		t.Errorf("requesting 101 user IDs: got error %v, want %v", err, ErrTooManyUserIDs)
+	}
+}
+
+func TestRequestGuildMembersFromHandler(t *testing.T) {
+	s, closeSession := newGuildMembersTestSession(t, make(chan requestGuildMembersData))
+	defer closeSession()
+
+	// The chunks are of the same guild as the message, so the dispatcher
+	// queues their handlers behind the message handler.
+	d := NewEventDispatcher(2, 10, EventOrderingGuild)
+	defer d.Close()
+	s.Dispatcher = d
+
+	members := make(chan int, 1)
+	s.AddHandler(func(s *Session, m *MessageCreate) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		result, err := s.RequestGuildMembersComplex(ctx, &RequestGuildMembersParams{GuildID: m.GuildID, UserIDs: []string{"a", "b"}})
+		if err != nil {
+			t.Errorf("RequestGuildMembersComplex returned error: %v", err)
+			members <- 0
+			return
+		}
+		members <- len(result.Members)
+	})
+	s.handleEvent(messageCreateEventType, &MessageCreate{&Message{GuildID: "guild"}})
+
+	if n := <-members; n != 2 {
+		t.Errorf("got %d members, want 2", n)
+	}
+}